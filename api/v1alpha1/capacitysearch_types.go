@@ -0,0 +1,150 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CapacitySearchJobStatus defines the status of the Experiments created by CapacitySearch.
+type CapacitySearchJobStatus string
+
+const (
+	CapacitySearchRunning   CapacitySearchJobStatus = "Running"
+	CapacitySearchCompleted CapacitySearchJobStatus = "Completed"
+	CapacitySearchFailed    CapacitySearchJobStatus = "Failed"
+)
+
+// CapacitySearchStrategy defines how CapacitySearch chooses the target rates to test.
+type CapacitySearchStrategy string
+
+const (
+	CapacitySearchStrategyStep   CapacitySearchStrategy = "Step"
+	CapacitySearchStrategyBinary CapacitySearchStrategy = "Binary"
+)
+
+// CapacitySearchSpec defines the desired state of CapacitySearch.
+// +kubebuilder:validation:XValidation:rule="self.minRate <= self.maxRate",message="minRate must not be greater than maxRate"
+type CapacitySearchSpec struct {
+	// Reference to the Pipeline to search the capacity of.
+	PipelineRef *corev1.LocalObjectReference `json:"pipelineRef"`
+	// Name of the endpoint to send the load to.
+	// It should be an existing endpoint defined in the Pipeline.
+	// +kubebuilder:validation:MinLength=1
+	EndpointName string `json:"endpointName"`
+	// Reference to the DataSet to be sent.
+	// The DataSet must be in the same namespace as the CapacitySearch.
+	DataSetRef *corev1.LocalObjectReference `json:"dataSetRef"`
+	// How to choose the target rates to test. Available values are `Step` and `Binary`.
+	// `Step` tests the rates from `minRate` to `maxRate` increasing by `step`, until a rate fails the SLO.
+	// `Binary` tests `minRate` and `maxRate` first, then bisects between the highest passing rate and
+	// the lowest failing rate until they are at most `step` apart.
+	// Default to `Step`.
+	// +kubebuilder:validation:Enum=Step;Binary
+	Strategy CapacitySearchStrategy `json:"strategy,omitempty"`
+	// Lowest target rate to test, in requests per second.
+	// +kubebuilder:validation:Minimum=1
+	MinRate int64 `json:"minRate"`
+	// Highest target rate to test, in requests per second.
+	// +kubebuilder:validation:Minimum=1
+	MaxRate int64 `json:"maxRate"`
+	// Increment of the target rate for the `Step` strategy, or the precision for the `Binary` strategy,
+	// in requests per second.
+	// +kubebuilder:validation:Minimum=1
+	Step int64 `json:"step"`
+	// Duration of the load at each target rate.
+	// Default to 5 minutes.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Number of VUs to pre-allocate for each target rate.
+	// Default to the target rate.
+	// +kubebuilder:validation:Minimum=0
+	PreAllocatedVUs int64 `json:"preAllocatedVUs,omitempty"`
+	// Maximum number of VUs to allow for each target rate.
+	// Default to 10 times the target rate.
+	// +kubebuilder:validation:Minimum=0
+	MaxVUs int64 `json:"maxVUs,omitempty"`
+	// Time to wait after the load generator job is completed before evaluating the SLO at each target rate.
+	// It allows the pipeline-under-test to finish its processing, and its backlog to be observed.
+	// Default to no draining time.
+	DrainingTime *metav1.Duration `json:"drainingTime,omitempty"`
+	// SLO that each target rate must meet to pass, e.g., on the latency, the error rate,
+	// or the backlog of the pipeline-under-test with a PromQL assertion.
+	SLO SLOSpec `json:"slo"`
+}
+
+// CapacitySearchPoint defines the result of a target rate tested by CapacitySearch.
+type CapacitySearchPoint struct {
+	// Target rate, in requests per second.
+	Rate int64 `json:"rate"`
+	// Name of the Experiment testing the target rate.
+	ExperimentName string `json:"experimentName"`
+	// Verdict of the SLO of the Experiment.
+	Verdict SLOVerdict `json:"verdict"`
+	// Achieved rate of requests per second.
+	// The value is a float number in string format.
+	AchievedRate string `json:"achievedRate,omitempty"`
+	// 50th percentile latency of the requests.
+	LatencyP50 *metav1.Duration `json:"latencyP50,omitempty"`
+	// 95th percentile latency of the requests.
+	LatencyP95 *metav1.Duration `json:"latencyP95,omitempty"`
+	// 99th percentile latency of the requests.
+	LatencyP99 *metav1.Duration `json:"latencyP99,omitempty"`
+}
+
+// CapacitySearchStatus defines the observed state of CapacitySearch.
+type CapacitySearchStatus struct {
+	// Status of the Experiments created by CapacitySearch.
+	JobStatus CapacitySearchJobStatus `json:"jobStatus,omitempty"`
+	// Highest target rate passing the SLO, in requests per second.
+	// Not set if no target rate passes the SLO.
+	MaxPassingRate *int64 `json:"maxPassingRate,omitempty"`
+	// Target rate being tested, in requests per second.
+	CurrentRate int64 `json:"currentRate,omitempty"`
+	// Name of the Experiment testing the current target rate.
+	CurrentExperimentName string `json:"currentExperimentName,omitempty"`
+	// Results of the tested target rates in the order they are tested.
+	Points []CapacitySearchPoint `json:"points,omitempty"`
+	// Time when the CapacitySearch started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Time when the CapacitySearch completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Error message.
+	Error string `json:"error,omitempty"`
+	// Conditions of the CapacitySearch, including `Ready`, `Progressing`, and `Degraded`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// The name of the Experiments created by the CapacitySearch will be
+// "<capacitysearch-name>-<up to 4 hex digits of point index>".
+// So, we have 27 characters for the name to meet the 32-character limit of the Experiment.
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="JobStatus",type="string",JSONPath=".status.jobStatus"
+//+kubebuilder:printcolumn:name="CurrentRate",type="integer",JSONPath=".status.currentRate"
+//+kubebuilder:printcolumn:name="MaxPassingRate",type="integer",JSONPath=".status.maxPassingRate"
+
+// CapacitySearch is the Schema for the capacitysearches API
+// +kubebuilder:validation:XValidation:rule="size(self.metadata.name) <= 27",message="must contain at most 27 characters"
+// +kubebuilder:validation:XValidation:rule="(has(self.spec.strategy) && self.spec.strategy == 'Binary') || (self.spec.maxRate - self.spec.minRate) / self.spec.step < 65535",message="must test at most 65535 rates"
+type CapacitySearch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CapacitySearchSpec   `json:"spec,omitempty"`
+	Status CapacitySearchStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CapacitySearchList contains a list of CapacitySearch
+type CapacitySearchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CapacitySearch `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CapacitySearch{}, &CapacitySearchList{})
+}
@@ -0,0 +1,36 @@
+package v1alpha1
+
+// Types of the conditions in the status of PlantD resources.
+// Conditions are only set when they apply to the kind of the resource.
+const (
+	// ConditionReady is true when the resource can be used, or when its work has completed successfully.
+	ConditionReady = "Ready"
+	// ConditionProgressing is true while the work of the resource is in progress.
+	// Its reason tells the current stage, e.g., `WaitingForDataSet` for an Experiment.
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded is true when the resource has failed or its spec is invalid.
+	ConditionDegraded = "Degraded"
+	// ConditionReferencesResolved is true when all objects referenced by the resource exist.
+	ConditionReferencesResolved = "ReferencesResolved"
+)
+
+// Reasons of the conditions in the status of PlantD resources,
+// besides the stages of the work used as the reasons of ConditionProgressing.
+const (
+	// ReasonReconciled means that the resource has been reconciled and has no work to do.
+	ReasonReconciled = "Reconciled"
+	// ReasonCompleted means that the work of the resource has completed successfully.
+	ReasonCompleted = "Completed"
+	// ReasonFailed means that the work of the resource has failed.
+	ReasonFailed = "Failed"
+	// ReasonInvalidSpec means that the spec of the resource is invalid.
+	ReasonInvalidSpec = "InvalidSpec"
+	// ReasonResolved means that all objects referenced by the resource exist.
+	ReasonResolved = "Resolved"
+	// ReasonReferenceNotFound means that an object referenced by the resource does not exist.
+	ReasonReferenceNotFound = "ReferenceNotFound"
+	// ReasonCancelled means that the work of the resource has been cancelled by the user.
+	ReasonCancelled = "Cancelled"
+	// ReasonSuspended means that the resource is suspended and does not create new objects.
+	ReasonSuspended = "Suspended"
+)
@@ -28,6 +28,10 @@ type CostExporterStatus struct {
 	LastFailure *metav1.Time `json:"lastFailure,omitempty"`
 	// Whether the Job is running. For internal use only.
 	IsRunning bool `json:"isRunning,omitempty"`
+	// Conditions of the CostExporter, including `Ready`, `Progressing`, and `Degraded`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // The name of the Pod in the CostExporter will be
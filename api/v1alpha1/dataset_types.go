@@ -46,12 +46,17 @@ type DataSetSpec struct {
 	// Default to 2Gi.
 	StorageSize *resource.Quantity `json:"storageSize,omitempty"`
 	// Format of the output file containing generated data.
-	// Available values are `csv` and `binary`.
-	// +kubebuilder.validation:Enum=csv;binary
+	// Available values are `csv`, `binary`, `json`, `ndjson`, `parquet`, and `avro`.
+	// Columns in `parquet` and `avro` files are typed by the output type of their gofakeit functions or formulas.
+	// +kubebuilder:validation:Enum=csv;binary;json;ndjson;parquet;avro
 	FileFormat string `json:"fileFormat"`
 	// Format of the compressed file containing output files.
-	// Available value is `zip`. Leave empty to disable compression.
-	// +kubebuilder:validation:Enum=zip
+	// Available values are `zip`, `tar.gz`, `gzip`, and `zstd`. Leave empty to disable compression.
+	// `zip` and `tar.gz` files are archives of the output files.
+	// `gzip` and `zstd` files contain a single output file, and are sent with the format of the output file
+	// as the Content-Type and the compression as the Content-Encoding. They require `compressPerSchema` to be `true`
+	// and `numFilesPerCompressedFile` to be exactly 1 in all Schemas.
+	// +kubebuilder:validation:Enum=zip;tar.gz;gzip;zstd
 	CompressedFileFormat string `json:"compressedFileFormat,omitempty"`
 	// Flag for compression behavior.
 	// Takes effect only if `compressedFileFormat` is set.
@@ -72,6 +77,12 @@ type DataSetSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=65535
 	Schemas []SchemaSelector `json:"schemas"`
+	// Seed for the random number generator.
+	// Each output file is generated from this seed and its sequence number, so that the same seed always
+	// produces the same output files regardless of `parallelism`, except for columns using time-dependent
+	// formulas like `CurrentTimeMs`.
+	// Leave empty to use a random seed, which will be recorded in the status.
+	Seed *int64 `json:"seed,omitempty"`
 }
 
 // DataSetStatus defines the observed state of DataSet.
@@ -90,6 +101,13 @@ type DataSetStatus struct {
 	Errors map[DataSetErrorType][]string `json:"errors,omitempty"`
 	// Last generation of the DataSet object. For internal use only.
 	LastGeneration int64 `json:"lastGeneration,omitempty"`
+	// Seed used by the data generator job.
+	// Equal to the `seed` field in the spec if set, otherwise randomly chosen.
+	Seed *int64 `json:"seed,omitempty"`
+	// Conditions of the DataSet, including `Ready`, `Progressing`, `Degraded`, and `ReferencesResolved`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // The name of the Pod in the DataSet will be
@@ -45,6 +45,10 @@ type DigitalTwinStatus struct {
 	JobStatus DigitalTwinJobStatus `json:"jobStatus,omitempty"`
 	// Error message.
 	Error string `json:"error,omitempty"`
+	// Conditions of the DigitalTwin, including `Ready`, `Progressing`, `Degraded`, and `ReferencesResolved`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // The name of the Experiment for the DigitalTwin will be
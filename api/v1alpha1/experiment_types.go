@@ -18,13 +18,18 @@ const (
 	ExperimentDraining        ExperimentJobStatus = "Draining"
 	ExperimentCompleted       ExperimentJobStatus = "Completed"
 	ExperimentFailed          ExperimentJobStatus = "Failed"
+	ExperimentCancelled       ExperimentJobStatus = "Cancelled"
 )
 
 // EndpointProtocol defines the protocol used by a PipelineEndpoint.
 type EndpointProtocol string
 
 const (
-	EndpointProtocolHTTP EndpointProtocol = "http"
+	EndpointProtocolHTTP      EndpointProtocol = "http"
+	EndpointProtocolGRPC      EndpointProtocol = "grpc"
+	EndpointProtocolKafka     EndpointProtocol = "kafka"
+	EndpointProtocolMQTT      EndpointProtocol = "mqtt"
+	EndpointProtocolWebSocket EndpointProtocol = "websocket"
 )
 
 // EndpointDataOption defines the data option used by an EndpointSpec.
@@ -35,6 +40,34 @@ const (
 	EndpointDataOptionDataSet   EndpointDataOption = "dataSet"
 )
 
+// SLOVerdict defines the verdict of the SLO of an Experiment.
+type SLOVerdict string
+
+const (
+	SLOPassed SLOVerdict = "Passed"
+	SLOFailed SLOVerdict = "Failed"
+)
+
+// ComparisonVerdict defines the verdict of the comparison of an Experiment with its baseline.
+type ComparisonVerdict string
+
+const (
+	ComparisonNoRegression ComparisonVerdict = "NoRegression"
+	ComparisonRegressed    ComparisonVerdict = "Regressed"
+)
+
+// SLOOperator defines the operator to compare the result of a PromQL assertion with its value.
+type SLOOperator string
+
+const (
+	SLOOperatorLessThan           SLOOperator = "<"
+	SLOOperatorLessThanOrEqual    SLOOperator = "<="
+	SLOOperatorGreaterThan        SLOOperator = ">"
+	SLOOperatorGreaterThanOrEqual SLOOperator = ">="
+	SLOOperatorEqual              SLOOperator = "=="
+	SLOOperatorNotEqual           SLOOperator = "!="
+)
+
 // DataSpec defines the data to be sent to an endpoint.
 type DataSpec struct {
 	// PlainText data to be sent.
@@ -59,6 +92,164 @@ type EndpointSpec struct {
 	// Only effective when `dataSpec.dataSetRef` is set.
 	// Default to the PVC size of the DataSet.
 	StorageSize *resource.Quantity `json:"storageSize,omitempty"`
+	// Name of the StorageClass of the PVC for the load generator job.
+	// Only effective when `dataSpec.dataSetRef` is set.
+	// Default to the default StorageClass.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Access mode of the PVC for the load generator job, which must be supported by the StorageClass.
+	// Only effective when `dataSpec.dataSetRef` is set.
+	// Default to `ReadWriteMany` if `parallelism` is greater than 1, so that the PVC can be shared by the runners
+	// on different nodes, and `ReadWriteOnce` otherwise.
+	// If the StorageClass does not support `ReadWriteMany`, use `ReadWriteOnce` and set `runnerNodeSelector` to
+	// schedule all the runners to the same node.
+	// +kubebuilder:validation:Enum=ReadWriteOnce;ReadWriteMany
+	StorageAccessMode corev1.PersistentVolumeAccessMode `json:"storageAccessMode,omitempty"`
+	// Number of K6 runners to generate the load for the endpoint in parallel.
+	// The rates and VUs of the LoadPattern are split across the runners, and each runner gets at least one VU.
+	// When greater than 1 and `dataSpec.dataSetRef` is set, the PVC for the load generator job is shared by the
+	// runners, see `storageAccessMode`.
+	// Default to 1.
+	// +kubebuilder:validation:Minimum=1
+	Parallelism int32 `json:"parallelism,omitempty"`
+	// Compute resources of each K6 runner.
+	// Default to no resource requests or limits.
+	RunnerResources *corev1.ResourceRequirements `json:"runnerResources,omitempty"`
+	// Node selector of the K6 runners.
+	// Default to no node selector.
+	RunnerNodeSelector map[string]string `json:"runnerNodeSelector,omitempty"`
+}
+
+// SLOAssertion defines a PromQL assertion in the SLO of an Experiment.
+type SLOAssertion struct {
+	// Name of the assertion.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// PromQL query evaluated at the completion time of the Experiment. It must return a single sample.
+	// The placeholders `${namespace}` and `${experiment}` are replaced by the namespace and name of the Experiment,
+	// and `${range}` by the duration of the Experiment in seconds, e.g., `300s`.
+	// The metrics scraped from the pipeline-under-test have the `job` label set to the name of the Experiment,
+	// e.g., `sum(increase(records_processed_total{job="${experiment}"}[${range}]))`.
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+	// Operator to compare the result of the query with the value.
+	// Available values are `<`, `<=`, `>`, `>=`, `==`, and `!=`.
+	// +kubebuilder:validation:Enum="<";"<=";">";">=";"==";"!="
+	Operator SLOOperator `json:"operator"`
+	// Value to compare the result of the query with.
+	// The value should be a float number in string format.
+	Value string `json:"value"`
+}
+
+// SLOSpec defines the pass/fail criteria of an Experiment.
+type SLOSpec struct {
+	// Maximum 95th percentile latency of the requests to each endpoint.
+	// The latency is measured by the `http_req_duration` metric of K6 for HTTP endpoints,
+	// the `grpc_req_duration` metric for gRPC endpoints, and the `iteration_duration` metric for other endpoints.
+	LatencyP95 *metav1.Duration `json:"latencyP95,omitempty"`
+	// Maximum rate of failed requests to each endpoint, i.e., the rate of failed checks in the load generator.
+	// The value should be a float number between 0 and 1 in string format.
+	ErrorRate string `json:"errorRate,omitempty"`
+	// Minimum ratio of the achieved rate of requests to the target rate of the LoadPattern of each endpoint.
+	// The value should be a float number between 0 and 1 in string format.
+	// Only endpoints using the "ramping-arrival-rate" or "constant-arrival-rate" executors have a target rate,
+	// the criterion is not met for other endpoints.
+	MinRateRatio string `json:"minRateRatio,omitempty"`
+	// List of PromQL assertions against the metrics scraped from the pipeline-under-test or the load generator.
+	Assertions []SLOAssertion `json:"assertions,omitempty"`
+}
+
+// SLOResult defines the result of a criterion in the SLO of an Experiment.
+type SLOResult struct {
+	// Name of the criterion, i.e., `latencyP95`, `errorRate`, `minRateRatio`, or the name of the assertion.
+	Name string `json:"name"`
+	// Name of the endpoint the criterion is evaluated upon. Not set for the assertions.
+	EndpointName string `json:"endpointName,omitempty"`
+	// Observed value, in seconds for `latencyP95`.
+	// Not set if the value cannot be observed.
+	Value string `json:"value,omitempty"`
+	// Operator and threshold the observed value is compared with, e.g., `<= 0.01`.
+	// The threshold is in seconds for `latencyP95`.
+	Threshold string `json:"threshold"`
+	// Whether the criterion is met.
+	Passed bool `json:"passed"`
+	// Message explaining why the value cannot be observed.
+	Message string `json:"message,omitempty"`
+}
+
+// RegressionTolerance defines how much worse the results of an Experiment can be than those of its baseline
+// before being flagged as regressions.
+type RegressionTolerance struct {
+	// Maximum relative increase of the 50th, 95th, and 99th percentile latency of the requests to each endpoint,
+	// e.g., `0.1` for 10%.
+	// The value should be a non-negative float number in string format.
+	// Default to `0.1`.
+	Latency string `json:"latency,omitempty"`
+	// Maximum relative decrease of the achieved rate of requests to each endpoint, e.g., `0.05` for 5%.
+	// The value should be a non-negative float number in string format.
+	// Default to `0.05`.
+	AchievedRate string `json:"achievedRate,omitempty"`
+	// Maximum absolute increase of the rate of failed requests to each endpoint, e.g., `0.01` for 1 percentage point.
+	// The value should be a non-negative float number in string format.
+	// Default to `0.01`.
+	ErrorRate string `json:"errorRate,omitempty"`
+	// Significance level of the one-sided Welch's t-test on whether the rate of requests sampled over the load
+	// generation is lower than the baseline.
+	// A decrease of the achieved rate beyond the tolerance is a regression only if the p-value of the test is lower
+	// than the significance level, i.e., the decrease is unlikely to be caused by fluctuations.
+	// The value should be a float number between 0 and 1 in string format.
+	// Default to `0.05`.
+	SignificanceLevel string `json:"significanceLevel,omitempty"`
+}
+
+// ComparisonResult defines the result of comparing a metric of an Experiment with that of its baseline.
+type ComparisonResult struct {
+	// Name of the metric, i.e., `latencyP50`, `latencyP95`, `latencyP99`, `achievedRate`, or `errorRate`.
+	Name string `json:"name"`
+	// Name of the endpoint the metric is observed upon.
+	EndpointName string `json:"endpointName"`
+	// Value of the Experiment, in seconds for the latency.
+	// Not set if the value cannot be observed.
+	Value string `json:"value,omitempty"`
+	// Value of the baseline, in seconds for the latency.
+	// Not set if the value cannot be observed.
+	BaselineValue string `json:"baselineValue,omitempty"`
+	// Change from the baseline value, relative to the baseline value except for `errorRate`.
+	// Positive if the value is worse than the baseline value, i.e., higher latency or error rate, or lower achieved rate.
+	Change string `json:"change,omitempty"`
+	// Maximum change allowed.
+	Tolerance string `json:"tolerance"`
+	// P-value of the one-sided Welch's t-test on whether the rate of requests is lower than the baseline.
+	// Only set for `achievedRate`.
+	PValue string `json:"pValue,omitempty"`
+	// Whether the metric regresses.
+	Regressed bool `json:"regressed"`
+	// Message explaining why the metric cannot be compared.
+	Message string `json:"message,omitempty"`
+}
+
+// EndpointSummary defines the summary of the load generated for an endpoint in an Experiment.
+// Fields are not set if their values cannot be observed.
+type EndpointSummary struct {
+	// Name of the endpoint.
+	EndpointName string `json:"endpointName"`
+	// Number of requests sent to the endpoint, i.e., the number of iterations of the load generator.
+	Requests *int64 `json:"requests,omitempty"`
+	// Achieved rate of requests per second, averaged over the duration calculated from the LoadPattern.
+	// The value is a float number in string format.
+	AchievedRate string `json:"achievedRate,omitempty"`
+	// 50th percentile latency of the requests.
+	// The latency is measured by the same K6 metric as the `latencyP95` field of the SLO.
+	LatencyP50 *metav1.Duration `json:"latencyP50,omitempty"`
+	// 95th percentile latency of the requests.
+	LatencyP95 *metav1.Duration `json:"latencyP95,omitempty"`
+	// 99th percentile latency of the requests.
+	LatencyP99 *metav1.Duration `json:"latencyP99,omitempty"`
+	// Number of HTTP requests with unexpected responses by the status code, where `0` means no response is received.
+	// Only set for HTTP endpoints.
+	HTTPErrors map[string]int64 `json:"httpErrors,omitempty"`
+	// Number of iterations the load generator failed to start, e.g., when running out of VUs because the endpoint
+	// responds slower than the target rate.
+	DroppedIterations *int64 `json:"droppedIterations,omitempty"`
 }
 
 // ExperimentSpec defines the desired state of Experiment.
@@ -79,6 +270,11 @@ type ExperimentSpec struct {
 	EndpointSpecs []EndpointSpec `json:"endpointSpecs"`
 	// Scheduled time to run the Experiment.
 	ScheduledTime *metav1.Time `json:"scheduledTime,omitempty"`
+	// Priority of the Experiment in the queue of the Pipeline.
+	// Experiments with higher priority acquire the Pipeline first, and Experiments with the same priority
+	// acquire it in the order of their scheduled time, or their creation time if not scheduled.
+	// Default to 0.
+	Priority int32 `json:"priority,omitempty"`
 	// Time to wait after the load generator job is completed before finishing the Experiment.
 	// It allows the pipeline-under-test to finish its processing.
 	// Default to no draining time.
@@ -88,6 +284,23 @@ type ExperimentSpec struct {
 	// after the load generator job completes.
 	// When set to `true`, the `drainingTime` field is ignored.
 	UseEndDetection bool `json:"useEndDetection,omitempty"`
+	// Whether to cancel the Experiment.
+	// When set to `true`, the load generator jobs are stopped, the draining is skipped, the Pipeline is released,
+	// and the Experiment finishes in the `Cancelled` status, keeping its start and completion time.
+	// Has no effect after the Experiment has completed or failed.
+	Cancel bool `json:"cancel,omitempty"`
+	// Pass/fail criteria evaluated after the draining of the Experiment.
+	// The Experiment passes if all criteria are met, and the verdict is recorded in the status.
+	// A failed verdict does not change the `Completed` status of the Experiment.
+	SLO *SLOSpec `json:"slo,omitempty"`
+	// Reference to a completed Experiment to compare the results with after the draining of the Experiment,
+	// e.g., the same Experiment run against the previous release of the pipeline-under-test.
+	// The baseline Experiment must be in the same namespace as the Experiment. Endpoints are matched by name.
+	// Regressions are recorded in the status, and do not change the `Completed` status of the Experiment.
+	BaselineRef *corev1.LocalObjectReference `json:"baselineRef,omitempty"`
+	// Tolerances of the comparison with the baseline Experiment.
+	// Only effective when `baselineRef` is set.
+	RegressionTolerance *RegressionTolerance `json:"regressionTolerance,omitempty"`
 }
 
 // ExperimentStatus defines the observed state of Experiment.
@@ -102,6 +315,23 @@ type ExperimentStatus struct {
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 	// Error message.
 	Error string `json:"error,omitempty"`
+	// Summary of the load generated for each endpoint, queried from Prometheus when the Experiment is completed.
+	Summaries []EndpointSummary `json:"summaries,omitempty"`
+	// Verdict of the SLO. Available values are `Passed` and `Failed`.
+	// Only set when the Experiment with `slo` set is completed.
+	SLOVerdict SLOVerdict `json:"sloVerdict,omitempty"`
+	// Results of the criteria in the SLO.
+	SLOResults []SLOResult `json:"sloResults,omitempty"`
+	// Verdict of the comparison with the baseline Experiment. Available values are `NoRegression` and `Regressed`.
+	// Only set when the Experiment with `baselineRef` set is completed, and the baseline Experiment is completed.
+	ComparisonVerdict ComparisonVerdict `json:"comparisonVerdict,omitempty"`
+	// Results of the comparison of each metric with the baseline Experiment.
+	ComparisonResults []ComparisonResult `json:"comparisonResults,omitempty"`
+	// Position of the Experiment in the queue of the Pipeline, starting from 1.
+	// Only set when the Experiment is waiting for the Pipeline.
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+	// Time when the Experiment started waiting for the pipeline-under-test. For internal use only.
+	WaitingPipelineStartTime *metav1.Time `json:"waitingPipelineStartTime,omitempty"`
 	// Time when the pipeline-under-test started draining. For internal use only.
 	DrainingStartTime *metav1.Time `json:"drainingStartTime,omitempty"`
 	// Whether to enable cost calculation.
@@ -113,6 +343,10 @@ type ExperimentStatus struct {
 	// Map of tags to select cloud resources. Equivalent to the tags in the cloud service provider.
 	// Copied from the Pipeline used by the Experiment. For internal use only.
 	Tags map[string]string `json:"tags,omitempty"`
+	// Conditions of the Experiment, including `Ready`, `Progressing`, `Degraded`, and `ReferencesResolved`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // The longest name of the Pod in the Experiment will be
@@ -126,6 +360,8 @@ type ExperimentStatus struct {
 //+kubebuilder:printcolumn:name="ScheduledTime",type="string",JSONPath=".spec.scheduledTime"
 //+kubebuilder:printcolumn:name="StartTime",type="string",JSONPath=".status.startTime"
 //+kubebuilder:printcolumn:name="CompletionTime",type="string",JSONPath=".status.completionTime"
+//+kubebuilder:printcolumn:name="SLO",type="string",JSONPath=".status.sloVerdict"
+//+kubebuilder:printcolumn:name="Comparison",type="string",JSONPath=".status.comparisonVerdict"
 
 // Experiment is the Schema for the experiments API
 // +kubebuilder:validation:XValidation:rule="size(self.metadata.name) <= 32",message="must contain at most 32 characters"
@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExperimentScheduleConcurrencyPolicy defines how to treat concurrent Experiments created by an ExperimentSchedule.
+type ExperimentScheduleConcurrencyPolicy string
+
+const (
+	ExperimentScheduleConcurrencyAllow   ExperimentScheduleConcurrencyPolicy = "Allow"
+	ExperimentScheduleConcurrencyForbid  ExperimentScheduleConcurrencyPolicy = "Forbid"
+	ExperimentScheduleConcurrencyReplace ExperimentScheduleConcurrencyPolicy = "Replace"
+)
+
+// ExperimentTemplateSpec defines the Experiments created by an ExperimentSchedule.
+type ExperimentTemplateSpec struct {
+	// Labels to add to the Experiments.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations to add to the Experiments.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Spec of the Experiments.
+	// The `scheduledTime` field is overwritten by the time the Experiment is scheduled.
+	Spec ExperimentSpec `json:"spec"`
+}
+
+// ExperimentScheduleSpec defines the desired state of ExperimentSchedule.
+type ExperimentScheduleSpec struct {
+	// Schedule in the Cron format, e.g., `0 2 * * *` for 2:00 AM every day.
+	// See https://en.wikipedia.org/wiki/Cron for more details.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// Deadline in seconds for creating the Experiment if it misses the scheduled time for any reason.
+	// Missed Experiments are skipped.
+	// Default to no deadline.
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// How to treat concurrent Experiments. Available values are `Allow`, `Forbid`, and `Replace`.
+	// `Allow` creates the Experiment even if the previous ones are not finished yet.
+	// `Forbid` skips the Experiment if the previous ones are not finished yet.
+	// `Replace` deletes the unfinished Experiments before creating the new one.
+	// Default to `Allow`.
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	ConcurrencyPolicy ExperimentScheduleConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// Whether to suspend creating new Experiments. Existing Experiments are not affected.
+	// Default to `false`.
+	Suspend bool `json:"suspend,omitempty"`
+	// Template of the Experiments to create.
+	ExperimentTemplate ExperimentTemplateSpec `json:"experimentTemplate"`
+	// Number of completed Experiments to keep.
+	// Default to 3.
+	// +kubebuilder:validation:Minimum=0
+	SuccessfulExperimentsHistoryLimit *int32 `json:"successfulExperimentsHistoryLimit,omitempty"`
+	// Number of failed or cancelled Experiments to keep.
+	// Default to 1.
+	// +kubebuilder:validation:Minimum=0
+	FailedExperimentsHistoryLimit *int32 `json:"failedExperimentsHistoryLimit,omitempty"`
+}
+
+// ExperimentScheduleStatus defines the observed state of ExperimentSchedule.
+type ExperimentScheduleStatus struct {
+	// References to the unfinished Experiments.
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+	// Time when the last Experiment was scheduled.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// Time when the next Experiment is scheduled.
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// Conditions of the ExperimentSchedule, including `Ready`, `Progressing`, and `Degraded`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// The name of the Experiments created by the ExperimentSchedule will be
+// "<experimentschedule-name>-<up to 8 digits of scheduled time in minutes>".
+// So, we have 23 characters for the name to meet the 32-character limit of the Experiment.
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+//+kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+//+kubebuilder:printcolumn:name="LastScheduleTime",type="string",JSONPath=".status.lastScheduleTime"
+//+kubebuilder:printcolumn:name="NextScheduleTime",type="string",JSONPath=".status.nextScheduleTime"
+
+// ExperimentSchedule is the Schema for the experimentschedules API
+// +kubebuilder:validation:XValidation:rule="size(self.metadata.name) <= 23",message="must contain at most 23 characters"
+type ExperimentSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExperimentScheduleSpec   `json:"spec,omitempty"`
+	Status ExperimentScheduleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ExperimentScheduleList contains a list of ExperimentSchedule
+type ExperimentScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExperimentSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ExperimentSchedule{}, &ExperimentScheduleList{})
+}
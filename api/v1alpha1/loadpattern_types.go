@@ -4,28 +4,51 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// LoadPatternExecutor defines the K6 executor used by a LoadPattern.
+type LoadPatternExecutor string
+
+const (
+	LoadPatternExecutorRampingArrivalRate  LoadPatternExecutor = "ramping-arrival-rate"
+	LoadPatternExecutorConstantVUs         LoadPatternExecutor = "constant-vus"
+	LoadPatternExecutorRampingVUs          LoadPatternExecutor = "ramping-vus"
+	LoadPatternExecutorConstantArrivalRate LoadPatternExecutor = "constant-arrival-rate"
+	LoadPatternExecutorPerVUIterations     LoadPatternExecutor = "per-vu-iterations"
+	LoadPatternExecutorSharedIterations    LoadPatternExecutor = "shared-iterations"
+)
+
 // Stage defines how the load ramps up or down.
 type Stage struct {
 	// Target load to reach at the end of the stage.
-	// Equivalent to the "ramping-arrival-rate" executor's `stages[].target` option in K6.
+	// It is the number of requests per `timeUnit` period for the "ramping-arrival-rate" executor,
+	// or the number of VUs for the "ramping-vus" executor.
+	// Equivalent to the executor's `stages[].target` option in K6.
 	// See https://k6.io/docs/using-k6/scenarios/executors/ramping-arrival-rate/#options for more details.
 	// +kubebuilder:validation:Minimum=0
 	Target int64 `json:"target"`
 	// Duration of the stage, also the time to reach the target load.
-	// Equivalent to the "ramping-arrival-rate" executor's `stages[].duration` option in K6.
+	// Equivalent to the executor's `stages[].duration` option in K6.
 	// See https://k6.io/docs/using-k6/scenarios/executors/ramping-arrival-rate/#options for more details.
 	Duration string `json:"duration"`
 }
 
 // LoadPatternSpec defines the desired state of LoadPattern.
 type LoadPatternSpec struct {
+	// K6 executor to generate the load. Available values are `ramping-arrival-rate`, `constant-vus`,
+	// `ramping-vus`, `constant-arrival-rate`, `per-vu-iterations`, and `shared-iterations`.
+	// Each executor uses a different set of the fields below.
+	// See https://k6.io/docs/using-k6/scenarios/executors/ for more details.
+	// Default to `ramping-arrival-rate`.
+	// +kubebuilder:validation:Enum=ramping-arrival-rate;constant-vus;ramping-vus;constant-arrival-rate;per-vu-iterations;shared-iterations
+	Executor LoadPatternExecutor `json:"executor,omitempty"`
 	// List of stages in the LoadPattern.
-	// Equivalent to the "ramping-arrival-rate" executor's `stages` option in K6.
+	// Equivalent to the `stages` option of the "ramping-arrival-rate" and "ramping-vus" executors in K6.
+	// Required by the "ramping-arrival-rate" and "ramping-vus" executors.
 	// See https://k6.io/docs/using-k6/scenarios/executors/ramping-arrival-rate/#options for more details.
 	// +kubebuilder:validation:MinItems=1
-	Stages []Stage `json:"stages"`
+	Stages []Stage `json:"stages,omitempty"`
 	// Number of VUs to pre-allocate before Experiment start.
-	// Equivalent to the "ramping-arrival-rate" executor's `preAllocatedVUs` option in K6.
+	// Equivalent to the `preAllocatedVUs` option of the "ramping-arrival-rate" and "constant-arrival-rate"
+	// executors in K6.
 	// See https://k6.io/docs/using-k6/scenarios/executors/ramping-arrival-rate/#options for more details.
 	// +kubebuilder:validation:Minimum=0
 	PreAllocatedVUs int64 `json:"preAllocatedVUs,omitempty"`
@@ -33,20 +56,62 @@ type LoadPatternSpec struct {
 	// Equivalent to the "ramping-arrival-rate" executor's `startRate` option in K6.
 	// See https://k6.io/docs/using-k6/scenarios/executors/ramping-arrival-rate/#options for more details.
 	// +kubebuilder:validation:Minimum=0
-	StartRate int64 `json:"startRate"`
-	// Period of time to apply to the `startRate` and `stages[].target` fields.
-	// Equivalent to the "ramping-arrival-rate" executor's `timeUnit` option in K6.
+	StartRate int64 `json:"startRate,omitempty"`
+	// Period of time to apply to the `startRate`, `stages[].target`, and `rate` fields.
+	// Equivalent to the `timeUnit` option of the "ramping-arrival-rate" and "constant-arrival-rate" executors in K6.
 	// See https://k6.io/docs/using-k6/scenarios/executors/ramping-arrival-rate/#options for more details.
 	TimeUnit string `json:"timeUnit,omitempty"`
 	// Maximum number of VUs to allow for allocation during Experiment.
-	// Equivalent to the "ramping-arrival-rate" executor's `maxVUs` option in K6.
+	// Equivalent to the `maxVUs` option of the "ramping-arrival-rate" and "constant-arrival-rate" executors in K6.
 	// See https://k6.io/docs/using-k6/scenarios/executors/ramping-arrival-rate/#options for more details.
 	// +kubebuilder:validation:Minimum=0
 	MaxVUs int64 `json:"maxVUs,omitempty"`
+	// Number of requests per `timeUnit` period.
+	// Equivalent to the "constant-arrival-rate" executor's `rate` option in K6.
+	// Required by the "constant-arrival-rate" executor.
+	// See https://k6.io/docs/using-k6/scenarios/executors/constant-arrival-rate/#options for more details.
+	// +kubebuilder:validation:Minimum=0
+	Rate int64 `json:"rate,omitempty"`
+	// Number of VUs to run concurrently.
+	// Equivalent to the `vus` option of the "constant-vus", "per-vu-iterations", and "shared-iterations"
+	// executors in K6.
+	// See https://k6.io/docs/using-k6/scenarios/executors/constant-vus/#options for more details.
+	// Default to 1.
+	// +kubebuilder:validation:Minimum=0
+	VUs int64 `json:"vus,omitempty"`
+	// Number of VUs to run at Experiment start.
+	// Equivalent to the "ramping-vus" executor's `startVUs` option in K6.
+	// See https://k6.io/docs/using-k6/scenarios/executors/ramping-vus/#options for more details.
+	// Default to 1.
+	// +kubebuilder:validation:Minimum=0
+	StartVUs int64 `json:"startVUs,omitempty"`
+	// Duration of the load generation.
+	// Equivalent to the `duration` option of the "constant-vus" and "constant-arrival-rate" executors in K6.
+	// Required by the "constant-vus" and "constant-arrival-rate" executors.
+	// See https://k6.io/docs/using-k6/scenarios/executors/constant-vus/#options for more details.
+	Duration string `json:"duration,omitempty"`
+	// Number of iterations, i.e., requests, to run by each VU for the "per-vu-iterations" executor,
+	// or in total for the "shared-iterations" executor.
+	// Equivalent to the executor's `iterations` option in K6.
+	// See https://k6.io/docs/using-k6/scenarios/executors/shared-iterations/#options for more details.
+	// Default to 1.
+	// +kubebuilder:validation:Minimum=0
+	Iterations int64 `json:"iterations,omitempty"`
+	// Maximum duration of the load generation before the iterations are forcibly stopped.
+	// Equivalent to the `maxDuration` option of the "per-vu-iterations" and "shared-iterations" executors in K6.
+	// It is also used as the duration of the Experiment for these executors.
+	// See https://k6.io/docs/using-k6/scenarios/executors/shared-iterations/#options for more details.
+	// Default to "10m".
+	MaxDuration string `json:"maxDuration,omitempty"`
 }
 
 // LoadPatternStatus defines the observed state of LoadPattern.
-type LoadPatternStatus struct{}
+type LoadPatternStatus struct {
+	// Conditions of the LoadPattern, including `Ready`, `Progressing`, and `Degraded`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
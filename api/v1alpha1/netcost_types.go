@@ -22,7 +22,12 @@ type NetCostSpec struct {
 }
 
 // NetCostStatus defines the observed state of NetCost.
-type NetCostStatus struct{}
+type NetCostStatus struct {
+	// Conditions of the NetCost, including `Ready`, `Progressing`, and `Degraded`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
@@ -13,6 +13,99 @@ const (
 	PipelineInUse PipelineAvailability = "In-Use"
 )
 
+// HMACAlgorithm defines the hash algorithm of the HMAC signature.
+type HMACAlgorithm string
+
+const (
+	HMACAlgorithmSHA1   HMACAlgorithm = "sha1"
+	HMACAlgorithmSHA256 HMACAlgorithm = "sha256"
+	HMACAlgorithmSHA512 HMACAlgorithm = "sha512"
+)
+
+// HMACEncoding defines the encoding of the HMAC signature.
+type HMACEncoding string
+
+const (
+	HMACEncodingHex    HMACEncoding = "hex"
+	HMACEncodingBase64 HMACEncoding = "base64"
+)
+
+// SecretHeader defines an HTTP header whose value is read from a Secret.
+type SecretHeader struct {
+	// Name of the header.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Reference to the key of the Secret containing the value of the header.
+	// The Secret must be in the same namespace as the Pipeline.
+	SecretKeyRef corev1.SecretKeySelector `json:"secretKeyRef"`
+}
+
+// BasicAuth defines the HTTP basic authentication.
+type BasicAuth struct {
+	// Reference to the key of the Secret containing the username.
+	// The Secret must be in the same namespace as the Pipeline.
+	Username corev1.SecretKeySelector `json:"username"`
+	// Reference to the key of the Secret containing the password.
+	// The Secret must be in the same namespace as the Pipeline.
+	Password corev1.SecretKeySelector `json:"password"`
+}
+
+// OAuth2ClientCredentials defines the OAuth 2.0 client credentials grant to fetch the bearer token.
+type OAuth2ClientCredentials struct {
+	// URL of the token endpoint.
+	// +kubebuilder:validation:MinLength=1
+	TokenURL string `json:"tokenURL"`
+	// Reference to the key of the Secret containing the client ID.
+	// The Secret must be in the same namespace as the Pipeline.
+	ClientID corev1.SecretKeySelector `json:"clientID"`
+	// Reference to the key of the Secret containing the client secret.
+	// The Secret must be in the same namespace as the Pipeline.
+	ClientSecret corev1.SecretKeySelector `json:"clientSecret"`
+	// Scopes to request.
+	// Default to no scope.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// HMACSignature defines the HMAC signature of the HTTP request body.
+type HMACSignature struct {
+	// Reference to the key of the Secret containing the signing key.
+	// The Secret must be in the same namespace as the Pipeline.
+	Key corev1.SecretKeySelector `json:"key"`
+	// Hash algorithm. Available values are `sha1`, `sha256`, and `sha512`.
+	// Default to `sha256`.
+	// +kubebuilder:validation:Enum=sha1;sha256;sha512
+	Algorithm HMACAlgorithm `json:"algorithm,omitempty"`
+	// Encoding of the signature. Available values are `hex` and `base64`.
+	// Default to `hex`.
+	// +kubebuilder:validation:Enum=hex;base64
+	Encoding HMACEncoding `json:"encoding,omitempty"`
+	// Name of the header to put the signature in.
+	// Default to "X-Signature".
+	Header string `json:"header,omitempty"`
+	// Prefix of the header value before the signature, e.g., "sha256=".
+	// Default to no prefix.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// HTTPAuth defines the authentication of HTTP requests.
+// The credentials are read from Secrets and passed to the load generator as environment variables.
+// At most one of `basic` and `oauth2` can be set, as both set the `Authorization` header.
+type HTTPAuth struct {
+	// HTTP basic authentication.
+	Basic *BasicAuth `json:"basic,omitempty"`
+	// OAuth 2.0 client credentials grant. The bearer token is fetched by each VU of the load generator,
+	// and fetched again before it expires. The token requests are excluded from the results of the Experiment.
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty"`
+	// HMAC signature of the request body.
+	// The signature is calculated over the exact request body, so files of DataSets not compressed as a stream
+	// are sent as the request body with their Content-Type, instead of as multipart form data.
+	HMAC *HMACSignature `json:"hmac,omitempty"`
+	// Reference to the Secret of type `kubernetes.io/tls` containing the client certificate and key for mTLS,
+	// in the `tls.crt` and `tls.key` keys.
+	// The Secret must be in the same namespace as the Pipeline.
+	ClientCertificateSecretRef *corev1.LocalObjectReference `json:"clientCertificateSecretRef,omitempty"`
+}
+
 // HTTP defines the configurations of HTTP protocol in endpoint.
 type HTTP struct {
 	// URL of the HTTP request.
@@ -20,15 +113,84 @@ type HTTP struct {
 	// Method of the HTTP request.
 	Method string `json:"method"`
 	// Headers of the HTTP request.
+	// The values are stored in plain text in the Pipeline and the load generator configurations.
+	// Use `secretHeaders` for sensitive values, e.g., API keys.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Headers of the HTTP request whose values are read from Secrets.
+	// They override the headers of the same name in `headers`.
+	SecretHeaders []SecretHeader `json:"secretHeaders,omitempty"`
+	// Authentication of the HTTP request.
+	Auth *HTTPAuth `json:"auth,omitempty"`
+}
+
+// GRPC defines the configurations of gRPC protocol in endpoint.
+// Each record of a DataSet is sent as a request message, so the DataSet must be uncompressed `json` or `ndjson`.
+type GRPC struct {
+	// Address of the gRPC server, in the form of "host:port".
+	Address string `json:"address"`
+	// Full name of the method to invoke, in the form of "package.Service/Method".
+	// The server must support the gRPC server reflection to resolve the method.
+	Method string `json:"method"`
+	// Metadata of the gRPC request.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Whether to connect to the server without TLS.
+	Plaintext bool `json:"plaintext,omitempty"`
+}
+
+// Kafka defines the configurations of Kafka protocol in endpoint.
+// The K6 runner image must be built with the xk6-kafka extension.
+type Kafka struct {
+	// Addresses of the Kafka brokers, in the form of "host:port".
+	// +kubebuilder:validation:MinItems=1
+	Brokers []string `json:"brokers"`
+	// Topic to produce the messages to.
+	Topic string `json:"topic"`
+	// Key of the messages.
+	// Default to no key.
+	Key string `json:"key,omitempty"`
+	// Headers of the messages.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// MQTT defines the configurations of MQTT protocol in endpoint.
+// The K6 runner image must be built with the xk6-mqtt extension.
+type MQTT struct {
+	// URL of the MQTT broker, e.g., "tcp://broker:1883".
+	URL string `json:"url"`
+	// Topic to publish the messages to.
+	Topic string `json:"topic"`
+	// QoS level of the messages. Available values are 0, 1, and 2.
+	// Default to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=2
+	QoS int32 `json:"qos,omitempty"`
+	// Whether the broker should retain the messages.
+	Retain bool `json:"retain,omitempty"`
+}
+
+// WebSocket defines the configurations of WebSocket protocol in endpoint.
+type WebSocket struct {
+	// URL of the WebSocket endpoint, e.g., "ws://host:port/path".
+	URL string `json:"url"`
+	// Headers of the handshake request.
 	Headers map[string]string `json:"headers,omitempty"`
 }
 
 // PipelineEndpoint defines the endpoint for data ingestion in Pipeline.
+// Exactly one of the protocols must be configured.
 type PipelineEndpoint struct {
 	// Name of the endpoint.
 	Name string `json:"name"`
 	// Configurations of the HTTP protocol.
-	HTTP *HTTP `json:"http"`
+	HTTP *HTTP `json:"http,omitempty"`
+	// Configurations of the gRPC protocol.
+	GRPC *GRPC `json:"grpc,omitempty"`
+	// Configurations of the Kafka protocol.
+	Kafka *Kafka `json:"kafka,omitempty"`
+	// Configurations of the MQTT protocol.
+	MQTT *MQTT `json:"mqtt,omitempty"`
+	// Configurations of the WebSocket protocol.
+	WebSocket *WebSocket `json:"websocket,omitempty"`
 }
 
 // MetricsEndpoint defines the endpoint for metrics scraping in Pipeline.
@@ -80,6 +242,14 @@ type PipelineSpec struct {
 type PipelineStatus struct {
 	// Availability of the Pipeline.
 	Availability PipelineAvailability `json:"availability,omitempty"`
+	// Name of the Experiment holding the Pipeline.
+	Holder string `json:"holder,omitempty"`
+	// Names of the Experiments waiting for the Pipeline, in the order they will acquire it.
+	Queue []string `json:"queue,omitempty"`
+	// Conditions of the Pipeline, including `Ready`, `Progressing`, and `Degraded`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // The name of the Service and ServiceMonitor for the Pipeline will be
@@ -139,6 +139,10 @@ type PlantDCoreStatus struct {
 	RedisStatus ComponentStatus `json:"redisStatus,omitempty"`
 	// OpenCost status.
 	OpenCostStatus ComponentStatus `json:"opencostStatus,omitempty"`
+	// Conditions of the PlantDCore, including `Ready`, `Progressing`, and `Degraded`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
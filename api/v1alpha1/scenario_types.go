@@ -28,7 +28,12 @@ type ScenarioSpec struct {
 }
 
 // ScenarioStatus defines the observed state of Scenario
-type ScenarioStatus struct{}
+type ScenarioStatus struct {
+	// Conditions of the Scenario, including `Ready`, `Progressing`, `Degraded`, and `ReferencesResolved`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
@@ -11,9 +11,28 @@ type Formula struct {
 	Name string `json:"name"`
 	// Arguments to be passed to the formula. Used together with the `name` field.
 	// See https://plantd.org/docs/reference/formulas for available values.
+	// The `Expression` formula takes a single expression over other columns of the same record, like
+	// `quantity > 10 ? price * quantity * (1 - discount) : price * quantity`, with arithmetic, comparison and logical
+	// operators, ternaries, and string, math and date functions. Columns are referred to by their names, quoted in
+	// backticks if they contain other characters than letters, digits, and underscores. Types are checked before
+	// the data is generated.
+	// The `TimeSeriesTimestamp` formula takes a start time, an interval, an optional jitter, and an optional column
+	// identifying the entity, and generates increasing timestamps in Unix milliseconds for each entity. The
+	// `TimeSeriesValue` formula takes a timestamp column, a base value, and optionally a trend per hour, the amplitude
+	// and period of a seasonal wave, and the standard deviation of the noise. Time series continue across the
+	// repetitions, each starting after the time window of the previous ones.
 	Args []string `json:"args,omitempty"`
 }
 
+// ColumnReference defines the reference to a column in a Schema.
+type ColumnReference struct {
+	// Name of the Schema containing the referenced column.
+	// The Schema must be in the same DataSet as the Schema containing the reference.
+	Schema string `json:"schema"`
+	// Name of the referenced column.
+	Column string `json:"column"`
+}
+
 // Column defines the column in Schema.
 type Column struct {
 	// Name of the column.
@@ -22,6 +41,9 @@ type Column struct {
 	// It should be a valid function name in gofakeit, which can be parsed by gofakeit.GetFuncLookup().
 	// `formula` field has precedence over this field.
 	// See https://plantd.org/docs/reference/types-and-params for available values.
+	// In addition, the statistical distributions `normal` (params `mean`, `stddev`), `lognormal` (`mu`, `sigma`),
+	// `exponential` (`rate`), `poisson` (`lambda`), `zipf` (`s`, `v`, `max`), and `categorical` (comma-separated
+	// `values` and optional `weights`) are available, drawing from the same seeded random number generator.
 	Type string `json:"type,omitempty"`
 	// Map of parameters for generating the data in the column. Used together with the `type` field.
 	// For any parameters not provided but required by the data type, the default value will be used, if available.
@@ -30,7 +52,15 @@ type Column struct {
 	Params map[string]string `json:"params,omitempty"`
 	// Formula to be applied for populating the data in the column.
 	// This field has precedence over the `type` fields.
+	// Columns in the same Schema used as arguments are populated first, regardless of the order they are declared
+	// in, and must not depend on each other in a cycle.
 	Formula Formula `json:"formula,omitempty"`
+	// Reference to a column, usually in another Schema, for populating the data in the column.
+	// Each value is copied from a random record of the referenced column generated in the same repetition,
+	// so that every value refers to an existing record in the output files of the DataSet.
+	// Schemas in a DataSet are generated after the Schemas they reference, and references must not form a cycle.
+	// Cannot be used together with the `type` and `formula` fields.
+	Reference *ColumnReference `json:"reference,omitempty"`
 }
 
 // SchemaSpec defines the desired state of Schema.
@@ -41,7 +71,12 @@ type SchemaSpec struct {
 }
 
 // SchemaStatus defines the observed state of Schema.
-type SchemaStatus struct{}
+type SchemaStatus struct {
+	// Conditions of the Schema, including `Ready`, `Progressing`, `Degraded`, and `ReferencesResolved`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
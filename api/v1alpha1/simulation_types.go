@@ -39,6 +39,10 @@ type SimulationStatus struct {
 	JobStatus SimulationJobStatus `json:"jobStatus,omitempty"`
 	// Error message.
 	Error string `json:"error,omitempty"`
+	// Conditions of the Simulation, including `Ready`, `Progressing`, `Degraded`, and `ReferencesResolved`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // The name of the Pod of the Simulation will be
@@ -11,7 +11,12 @@ type TrafficModelSpec struct {
 }
 
 // TrafficModelStatus defines the observed state of TrafficModel.
-type TrafficModelStatus struct{}
+type TrafficModelStatus struct {
+	// Conditions of the TrafficModel, including `Ready`, `Progressing`, and `Degraded`.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
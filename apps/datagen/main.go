@@ -26,6 +26,11 @@ func main() {
 		log.Panic(err)
 	}
 
+	seed, err := strconv.ParseInt(os.Getenv("SEED"), 10, 64)
+	if err != nil {
+		log.Panic(err)
+	}
+
 	repeatStart := jobIndex * jobSize
 	repeatEnd := min(repeatStart+jobSize, totalRepeat)
 
@@ -43,8 +48,14 @@ func main() {
 
 	path := os.Getenv("OUTPUT_PATH")
 
-	job := datagen.NewBuilderBasedDataGeneratorJob(repeatStart, repeatEnd, &dataSet, schemaMap)
+	job := datagen.NewBuilderBasedDataGeneratorJob(repeatStart, repeatEnd, seed, &dataSet, schemaMap)
 	if err := job.GenerateData(path); err != nil {
 		log.Panic(err)
 	}
+
+	// Report the size of the output to the operator
+	bytesWritten := strconv.FormatInt(job.GetBytesWritten(), 10)
+	if err := os.WriteFile(datagen.TerminationMessagePath, []byte(bytesWritten), 0644); err != nil {
+		log.Printf("Cannot write termination message: %s", err)
+	}
 }
@@ -0,0 +1,70 @@
+import grpc from 'k6/net/grpc';
+import { check } from 'k6';
+import { randomIntBetween } from "https://jslib.k6.io/k6-utils/1.4.0/index.js";
+
+const endpoint = JSON.parse(open('endpoint.json'));
+const dataSet = JSON.parse(open('dataset.json'));
+// Scenario of the executor used by the LoadPattern, built by the operator
+const scenario = JSON.parse(open('scenario.json'));
+
+const address = endpoint.grpc.address;
+const method = endpoint.grpc.method;
+const metadata = endpoint.grpc.metadata || {};
+const plaintext = endpoint.grpc.plaintext || false;
+
+const dataSetName = dataSet.metadata.name;
+const numFiles = dataSet.spec.numFiles;
+const numSchemas = dataSet.spec.schemas.length;
+const compressedFileFormat = dataSet.spec.compressedFileFormat || "";
+const fileFormat = dataSet.spec.fileFormat;
+
+// Each record is sent as a request message, so the files must be uncompressed JSON or NDJSON files.
+// The Experiment webhook rejects other DataSets, this only guards against DataSets changed afterwards.
+if ((fileFormat !== 'json' && fileFormat !== 'ndjson') || compressedFileFormat !== "") {
+  throw new Error(`gRPC endpoints only support uncompressed JSON or NDJSON files, got "${fileFormat}" compressed by "${compressedFileFormat}"`);
+}
+
+// Records in a file, which is a JSON array of objects or has an object per line
+function parseRecords(content) {
+  if (fileFormat === 'json') {
+    return JSON.parse(content);
+  }
+  return content.split('\n').filter((line) => line.trim() !== '').map((line) => JSON.parse(line));
+}
+
+function recordArray() {
+  const arr = [];
+  for (let i = 0; i < numSchemas; i++) {
+    const schemaName = dataSet.spec.schemas[i].name;
+    for (let j = 0; j < numFiles; j++) {
+      const fname = `${schemaName}/${dataSetName}_${schemaName}_${j}.${fileFormat}`;
+      parseRecords(open(fname)).forEach((record) => arr.push(record));
+    }
+  }
+  return arr;
+}
+
+const dataCache = recordArray();
+const maxIndex = dataCache.length - 1;
+
+const client = new grpc.Client();
+
+export const options = {
+  scenarios: {
+    sendDataSet: scenario,
+  },
+  discardResponseBodies: true,
+  noVUConnectionReuse: true,
+};
+
+export default function () {
+  const i = randomIntBetween(0, maxIndex)
+  client.connect(address, { plaintext: plaintext, reflect: true });
+  const res = client.invoke(method, dataCache[i], {
+    metadata: metadata,
+  });
+  check(res, {
+    'status was OK': (r) => r && r.status === grpc.StatusOK,
+  });
+  client.close();
+}
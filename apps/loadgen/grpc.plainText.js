@@ -0,0 +1,35 @@
+import grpc from 'k6/net/grpc';
+import { check } from 'k6';
+
+const endpoint = JSON.parse(open('endpoint.json'));
+const plainText = open('plaintext.txt');
+// Scenario of the executor used by the LoadPattern, built by the operator
+const scenario = JSON.parse(open('scenario.json'));
+
+const address = endpoint.grpc.address;
+const method = endpoint.grpc.method;
+const metadata = endpoint.grpc.metadata || {};
+const plaintext = endpoint.grpc.plaintext || false;
+// The plain text is the request message in JSON
+const data = JSON.parse(plainText);
+
+const client = new grpc.Client();
+
+export const options = {
+  scenarios: {
+    sendPlainText: scenario,
+  },
+  discardResponseBodies: true,
+  noVUConnectionReuse: true,
+};
+
+export default function () {
+  client.connect(address, { plaintext: plaintext, reflect: true });
+  const res = client.invoke(method, data, {
+    metadata: metadata,
+  });
+  check(res, {
+    'status was OK': (r) => r && r.status === grpc.StatusOK,
+  });
+  client.close();
+}
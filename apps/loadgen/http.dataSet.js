@@ -1,15 +1,83 @@
 import http from 'k6/http';
-import { check } from 'k6';
+import crypto from 'k6/crypto';
+import encoding from 'k6/encoding';
+import { check, group } from 'k6';
 import { randomIntBetween } from "https://jslib.k6.io/k6-utils/1.4.0/index.js";
 
 const endpoint = JSON.parse(open('endpoint.json'));
 const dataSet = JSON.parse(open('dataset.json'));
-const loadPattern = JSON.parse(open('loadpattern.json'));
+// Scenario of the executor used by the LoadPattern, built by the operator
+const scenario = JSON.parse(open('scenario.json'));
 
 const url = endpoint.http.url;
 const method = endpoint.http.method;
 const headers = endpoint.http.headers || {};
 
+const auth = endpoint.http.auth || {};
+
+// The credentials are read from the environment variables set from the Secrets by the operator
+const authHeaders = {};
+(endpoint.http.secretHeaders || []).forEach((secretHeader, i) => {
+  authHeaders[secretHeader.name] = __ENV[`PLANTD_HTTP_SECRET_HEADER_${i}`];
+});
+if (auth.basic) {
+  const credentials = `${__ENV.PLANTD_HTTP_BASIC_USERNAME}:${__ENV.PLANTD_HTTP_BASIC_PASSWORD}`;
+  authHeaders['Authorization'] = `Basic ${encoding.b64encode(credentials)}`;
+}
+
+// Client certificate for mTLS
+const tlsAuth = __ENV.PLANTD_HTTP_TLS_CERT ? [{
+  cert: __ENV.PLANTD_HTTP_TLS_CERT,
+  key: __ENV.PLANTD_HTTP_TLS_KEY,
+}] : undefined;
+
+// Bearer token of the VU fetched by the OAuth 2.0 client credentials grant, and the time to fetch it again
+let token = null;
+let tokenRefreshTime = 0;
+
+function getToken() {
+  if (token !== null && Date.now() < tokenRefreshTime) {
+    return token;
+  }
+  // The token requests are grouped so that they are excluded from the results of the Experiment
+  group('oauth2', function () {
+    const body = {
+      grant_type: 'client_credentials',
+      client_id: __ENV.PLANTD_HTTP_OAUTH2_CLIENT_ID,
+      client_secret: __ENV.PLANTD_HTTP_OAUTH2_CLIENT_SECRET,
+    };
+    if (auth.oauth2.scopes) {
+      body.scope = auth.oauth2.scopes.join(' ');
+    }
+    const res = http.post(auth.oauth2.tokenURL, body, { responseType: 'text' });
+    if (res.status === 200) {
+      const data = res.json();
+      token = data.access_token;
+      // Fetch the token again 30 seconds before it expires, or after 5 minutes if the expiry is unknown
+      tokenRefreshTime = Date.now() + Math.max((data.expires_in || 300) - 30, 1) * 1000;
+    } else {
+      token = null;
+    }
+  });
+  return token;
+}
+
+// Headers to authenticate the request with the given body
+function getAuthHeaders(body) {
+  const requestHeaders = Object.assign({}, authHeaders);
+  if (auth.oauth2) {
+    const accessToken = getToken();
+    if (accessToken !== null) {
+      requestHeaders['Authorization'] = `Bearer ${accessToken}`;
+    }
+  }
+  if (auth.hmac) {
+    const signature = crypto.hmac(auth.hmac.algorithm || 'sha256', __ENV.PLANTD_HTTP_HMAC_KEY, body, auth.hmac.encoding || 'hex');
+    requestHeaders[auth.hmac.header || 'X-Signature'] = (auth.hmac.prefix || '') + signature;
+  }
+  return requestHeaders;
+}
+
 const dataSetName = dataSet.metadata.name;
 const numFiles = dataSet.spec.numFiles;
 const numSchemas = dataSet.spec.schemas.length;
@@ -19,10 +87,43 @@ const compressPerSchema = dataSet.spec.compressPerSchema || false;
 
 const fileExtensions = {
   csv: 'csv',
-  binary: 'bin'
+  binary: 'bin',
+  json: 'json',
+  ndjson: 'ndjson',
+  parquet: 'parquet',
+  avro: 'avro'
 };
 const ext = fileExtensions[fileFormat];
 
+const contentTypes = {
+  csv: 'text/csv',
+  binary: 'application/octet-stream',
+  json: 'application/json',
+  ndjson: 'application/x-ndjson',
+  parquet: 'application/vnd.apache.parquet',
+  avro: 'application/avro',
+  zip: 'application/zip',
+  'tar.gz': 'application/x-tar'
+};
+// Files compressed as a stream are sent as the request body, with the format of the decompressed
+// content as the Content-Type, so that the endpoint can decode them by the Content-Encoding
+const contentType = contentTypes[compressedFileFormat] || contentTypes[fileFormat];
+
+const compressedFileExtensions = {
+  zip: 'zip',
+  'tar.gz': 'tar.gz',
+  gzip: 'gz',
+  zstd: 'zst'
+};
+const compressedExt = compressedFileExtensions[compressedFileFormat];
+
+const contentEncodings = {
+  'tar.gz': 'gzip',
+  gzip: 'gzip',
+  zstd: 'zstd'
+};
+const contentEncoding = contentEncodings[compressedFileFormat];
+
 function filePerSchemaArray() {
   const n = numSchemas * numFiles;
   const arr = new Array(n);
@@ -47,7 +148,7 @@ function filePerCompressedPerSchemaArray() {
     let k = i * numFiles;
     let schemaName = dataSet.spec.schemas[i].name;
     for (let j = 0; j < numFiles; j++) {
-      const fname = `${dataSetName}_${schemaName}_${j}.${compressedFileFormat}`;
+      const fname = `${dataSetName}_${schemaName}_${j}.${compressedExt}`;
       arr[k + j] = {
         name: fname,
         content: open(fname, 'b')
@@ -60,7 +161,7 @@ function filePerCompressedPerSchemaArray() {
 function filePerCompressedArray() {
   const arr = new Array(numFiles);
   for (let i = 0; i < numFiles; i++) {
-    const fname = `${dataSetName}_${i}.${compressedFileFormat}`;
+    const fname = `${dataSetName}_${i}.${compressedExt}`;
     arr[i] = {
       name: fname,
       content: open(fname, 'b')
@@ -84,27 +185,39 @@ if (compressedFileFormat === "") {
 
 export const options = {
   scenarios: {
-    sendDataSet: {
-      executor: 'ramping-arrival-rate',
-      startRate: loadPattern.spec.startRate,
-      timeUnit: loadPattern.spec.timeUnit,
-      preAllocatedVUs: loadPattern.spec.preAllocatedVUs,
-      maxVUs: loadPattern.spec.maxVUs,
-      stages: loadPattern.spec.stages,
-    },
+    sendDataSet: scenario,
   },
   discardResponseBodies: true,
   noVUConnectionReuse: true,
+  tlsAuth: tlsAuth,
 };
 
 export default function () {
   const i = randomIntBetween(0, maxIndex)
-  let payload = {
-    file: http.file(dataCache[i]['content'], dataCache[i]['name'], 'multipart/form-data'),
-  };
-  let res = http.request(method, url, payload, {
-    headers: headers,
-  });
+  let res;
+  if (contentEncoding !== undefined) {
+    res = http.request(method, url, dataCache[i]['content'], {
+      headers: Object.assign({}, headers, getAuthHeaders(dataCache[i]['content']), {
+        'Content-Type': contentType,
+        'Content-Encoding': contentEncoding,
+      }),
+    });
+  } else if (auth.hmac) {
+    // The multipart body is encoded by K6 with a random boundary, so the file is sent as the request body
+    // instead, to calculate the signature over the exact bytes sent
+    res = http.request(method, url, dataCache[i]['content'], {
+      headers: Object.assign({}, headers, getAuthHeaders(dataCache[i]['content']), {
+        'Content-Type': contentType,
+      }),
+    });
+  } else {
+    let payload = {
+      file: http.file(dataCache[i]['content'], dataCache[i]['name'], contentType),
+    };
+    res = http.request(method, url, payload, {
+      headers: Object.assign({}, headers, getAuthHeaders()),
+    });
+  }
   check(res, {
     'status was 200': (r) => r.status === 200,
   });
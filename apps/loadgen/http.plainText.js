@@ -1,33 +1,95 @@
 import http from 'k6/http';
-import { check } from 'k6';
+import crypto from 'k6/crypto';
+import encoding from 'k6/encoding';
+import { check, group } from 'k6';
 
 let endpoint = JSON.parse(open('endpoint.json'));
 let plainText = open('plaintext.txt');
-let loadPattern = JSON.parse(open('loadpattern.json'));
+// Scenario of the executor used by the LoadPattern, built by the operator
+const scenario = JSON.parse(open('scenario.json'));
 
 const url = endpoint.http.url;
 const method = endpoint.http.method;
 const headers = endpoint.http.headers || {};
 const data = plainText;
 
+const auth = endpoint.http.auth || {};
+
+// The credentials are read from the environment variables set from the Secrets by the operator
+const authHeaders = {};
+(endpoint.http.secretHeaders || []).forEach((secretHeader, i) => {
+  authHeaders[secretHeader.name] = __ENV[`PLANTD_HTTP_SECRET_HEADER_${i}`];
+});
+if (auth.basic) {
+  const credentials = `${__ENV.PLANTD_HTTP_BASIC_USERNAME}:${__ENV.PLANTD_HTTP_BASIC_PASSWORD}`;
+  authHeaders['Authorization'] = `Basic ${encoding.b64encode(credentials)}`;
+}
+
+// Client certificate for mTLS
+const tlsAuth = __ENV.PLANTD_HTTP_TLS_CERT ? [{
+  cert: __ENV.PLANTD_HTTP_TLS_CERT,
+  key: __ENV.PLANTD_HTTP_TLS_KEY,
+}] : undefined;
+
+// Bearer token of the VU fetched by the OAuth 2.0 client credentials grant, and the time to fetch it again
+let token = null;
+let tokenRefreshTime = 0;
+
+function getToken() {
+  if (token !== null && Date.now() < tokenRefreshTime) {
+    return token;
+  }
+  // The token requests are grouped so that they are excluded from the results of the Experiment
+  group('oauth2', function () {
+    const body = {
+      grant_type: 'client_credentials',
+      client_id: __ENV.PLANTD_HTTP_OAUTH2_CLIENT_ID,
+      client_secret: __ENV.PLANTD_HTTP_OAUTH2_CLIENT_SECRET,
+    };
+    if (auth.oauth2.scopes) {
+      body.scope = auth.oauth2.scopes.join(' ');
+    }
+    const res = http.post(auth.oauth2.tokenURL, body, { responseType: 'text' });
+    if (res.status === 200) {
+      const data = res.json();
+      token = data.access_token;
+      // Fetch the token again 30 seconds before it expires, or after 5 minutes if the expiry is unknown
+      tokenRefreshTime = Date.now() + Math.max((data.expires_in || 300) - 30, 1) * 1000;
+    } else {
+      token = null;
+    }
+  });
+  return token;
+}
+
+// Headers to authenticate the request with the given body
+function getAuthHeaders(body) {
+  const requestHeaders = Object.assign({}, authHeaders);
+  if (auth.oauth2) {
+    const accessToken = getToken();
+    if (accessToken !== null) {
+      requestHeaders['Authorization'] = `Bearer ${accessToken}`;
+    }
+  }
+  if (auth.hmac) {
+    const signature = crypto.hmac(auth.hmac.algorithm || 'sha256', __ENV.PLANTD_HTTP_HMAC_KEY, body, auth.hmac.encoding || 'hex');
+    requestHeaders[auth.hmac.header || 'X-Signature'] = (auth.hmac.prefix || '') + signature;
+  }
+  return requestHeaders;
+}
+
 export let options = {
   scenarios: {
-    sendPlainText: {
-      executor: 'ramping-arrival-rate',
-      startRate: loadPattern.spec.startRate,
-      timeUnit: loadPattern.spec.timeUnit,
-      preAllocatedVUs: loadPattern.spec.preAllocatedVUs,
-      maxVUs: loadPattern.spec.maxVUs,
-      stages: loadPattern.spec.stages,
-    },
+    sendPlainText: scenario,
   },
   discardResponseBodies: true,
   noVUConnectionReuse: true,
+  tlsAuth: tlsAuth,
 };
 
 export default function () {
   let res = http.request(method, url, data, {
-    headers: headers,
+    headers: Object.assign({}, headers, getAuthHeaders(data)),
   });
   check(res, {
     'status was 200': (r) => r.status === 200,
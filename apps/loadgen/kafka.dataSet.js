@@ -0,0 +1,150 @@
+import { Writer, SchemaRegistry, SCHEMA_TYPE_STRING, SCHEMA_TYPE_BYTES } from 'k6/x/kafka';
+import { check } from 'k6';
+import { randomIntBetween } from "https://jslib.k6.io/k6-utils/1.4.0/index.js";
+
+const endpoint = JSON.parse(open('endpoint.json'));
+const dataSet = JSON.parse(open('dataset.json'));
+// Scenario of the executor used by the LoadPattern, built by the operator
+const scenario = JSON.parse(open('scenario.json'));
+
+const brokers = endpoint.kafka.brokers;
+const topic = endpoint.kafka.topic;
+const key = endpoint.kafka.key || "";
+const headers = endpoint.kafka.headers || {};
+
+const dataSetName = dataSet.metadata.name;
+const numFiles = dataSet.spec.numFiles;
+const numSchemas = dataSet.spec.schemas.length;
+const compressedFileFormat = dataSet.spec.compressedFileFormat || "";
+const fileFormat = dataSet.spec.fileFormat;
+const compressPerSchema = dataSet.spec.compressPerSchema || false;
+
+const fileExtensions = {
+  csv: 'csv',
+  binary: 'bin',
+  json: 'json',
+  ndjson: 'ndjson',
+  parquet: 'parquet',
+  avro: 'avro'
+};
+const ext = fileExtensions[fileFormat];
+
+const compressedFileExtensions = {
+  zip: 'zip',
+  'tar.gz': 'tar.gz',
+  gzip: 'gz',
+  zstd: 'zst'
+};
+const compressedExt = compressedFileExtensions[compressedFileFormat];
+
+function filePerSchemaArray() {
+  const n = numSchemas * numFiles;
+  const arr = new Array(n);
+  for (let i = 0; i < numSchemas; i++) {
+    let k = i * numFiles;
+    const schemaName = dataSet.spec.schemas[i].name;
+    for (let j = 0; j < numFiles; j++) {
+      const fname = `${schemaName}/${dataSetName}_${schemaName}_${j}.${ext}`;
+      arr[k + j] = {
+        name: fname,
+        content: open(fname, 'b')
+      };
+    }
+  }
+  return arr;
+}
+
+function filePerCompressedPerSchemaArray() {
+  const n = numSchemas * numFiles;
+  const arr = new Array(n);
+  for (let i = 0; i < numSchemas; i++) {
+    let k = i * numFiles;
+    let schemaName = dataSet.spec.schemas[i].name;
+    for (let j = 0; j < numFiles; j++) {
+      const fname = `${dataSetName}_${schemaName}_${j}.${compressedExt}`;
+      arr[k + j] = {
+        name: fname,
+        content: open(fname, 'b')
+      };
+    }
+  }
+  return arr;
+}
+
+function filePerCompressedArray() {
+  const arr = new Array(numFiles);
+  for (let i = 0; i < numFiles; i++) {
+    const fname = `${dataSetName}_${i}.${compressedExt}`;
+    arr[i] = {
+      name: fname,
+      content: open(fname, 'b')
+    };
+  }
+  return arr;
+}
+
+let maxIndex;
+let dataCache;
+if (compressedFileFormat === "") {
+  maxIndex = numSchemas * numFiles - 1;
+  dataCache = filePerSchemaArray()
+} else if (compressPerSchema === true) {
+  maxIndex = numSchemas * numFiles - 1;
+  dataCache = filePerCompressedPerSchemaArray()
+} else {
+  maxIndex = numFiles - 1;
+  dataCache = filePerCompressedArray()
+}
+
+const writer = new Writer({
+  brokers: brokers,
+  topic: topic,
+});
+const schemaRegistry = new SchemaRegistry();
+
+// Each file is sent as the value of a message in bytes
+const messageCache = dataCache.map((file) => {
+  const message = {
+    value: schemaRegistry.serialize({
+      data: Array.from(new Uint8Array(file['content'])),
+      schemaType: SCHEMA_TYPE_BYTES,
+    }),
+    headers: Object.assign({}, headers, {
+      filename: file['name'],
+    }),
+  };
+  if (key !== "") {
+    message.key = schemaRegistry.serialize({
+      data: key,
+      schemaType: SCHEMA_TYPE_STRING,
+    });
+  }
+  return message;
+});
+
+export const options = {
+  scenarios: {
+    sendDataSet: scenario,
+  },
+  discardResponseBodies: true,
+  noVUConnectionReuse: true,
+};
+
+export default function () {
+  const i = randomIntBetween(0, maxIndex)
+  let err;
+  try {
+    writer.produce({
+      messages: [messageCache[i]],
+    });
+  } catch (e) {
+    err = e;
+  }
+  check(err, {
+    'message was produced': (e) => e === undefined,
+  });
+}
+
+export function teardown() {
+  writer.close();
+}
@@ -0,0 +1,58 @@
+import { Writer, SchemaRegistry, SCHEMA_TYPE_STRING } from 'k6/x/kafka';
+import { check } from 'k6';
+
+const endpoint = JSON.parse(open('endpoint.json'));
+const plainText = open('plaintext.txt');
+// Scenario of the executor used by the LoadPattern, built by the operator
+const scenario = JSON.parse(open('scenario.json'));
+
+const brokers = endpoint.kafka.brokers;
+const topic = endpoint.kafka.topic;
+const key = endpoint.kafka.key || "";
+const headers = endpoint.kafka.headers || {};
+
+const writer = new Writer({
+  brokers: brokers,
+  topic: topic,
+});
+const schemaRegistry = new SchemaRegistry();
+
+const message = {
+  value: schemaRegistry.serialize({
+    data: plainText,
+    schemaType: SCHEMA_TYPE_STRING,
+  }),
+  headers: headers,
+};
+if (key !== "") {
+  message.key = schemaRegistry.serialize({
+    data: key,
+    schemaType: SCHEMA_TYPE_STRING,
+  });
+}
+
+export const options = {
+  scenarios: {
+    sendPlainText: scenario,
+  },
+  discardResponseBodies: true,
+  noVUConnectionReuse: true,
+};
+
+export default function () {
+  let err;
+  try {
+    writer.produce({
+      messages: [message],
+    });
+  } catch (e) {
+    err = e;
+  }
+  check(err, {
+    'message was produced': (e) => e === undefined,
+  });
+}
+
+export function teardown() {
+  writer.close();
+}
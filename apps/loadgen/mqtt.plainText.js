@@ -0,0 +1,39 @@
+import mqtt from 'k6/x/mqtt';
+import { check } from 'k6';
+
+const endpoint = JSON.parse(open('endpoint.json'));
+const plainText = open('plaintext.txt');
+// Scenario of the executor used by the LoadPattern, built by the operator
+const scenario = JSON.parse(open('scenario.json'));
+
+const url = endpoint.mqtt.url;
+const topic = endpoint.mqtt.topic;
+const qos = endpoint.mqtt.qos || 0;
+const retain = endpoint.mqtt.retain || false;
+const data = plainText;
+
+// Timeout of connecting and publishing in milliseconds
+const timeout = 10000;
+
+export const options = {
+  scenarios: {
+    sendPlainText: scenario,
+  },
+  discardResponseBodies: true,
+  noVUConnectionReuse: true,
+};
+
+export default function () {
+  const publisher = new mqtt.Client([url], "", "", true, `plantd-${__VU}-${__ITER}`, timeout);
+  let err;
+  try {
+    publisher.connect();
+    publisher.publish(topic, qos, data, retain, timeout);
+  } catch (e) {
+    err = e;
+  }
+  check(err, {
+    'message was published': (e) => e === undefined,
+  });
+  publisher.close(timeout);
+}
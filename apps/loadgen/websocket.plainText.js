@@ -0,0 +1,31 @@
+import ws from 'k6/ws';
+import { check } from 'k6';
+
+const endpoint = JSON.parse(open('endpoint.json'));
+const plainText = open('plaintext.txt');
+// Scenario of the executor used by the LoadPattern, built by the operator
+const scenario = JSON.parse(open('scenario.json'));
+
+const url = endpoint.websocket.url;
+const headers = endpoint.websocket.headers || {};
+const data = plainText;
+
+export const options = {
+  scenarios: {
+    sendPlainText: scenario,
+  },
+  discardResponseBodies: true,
+  noVUConnectionReuse: true,
+};
+
+export default function () {
+  const res = ws.connect(url, { headers: headers }, function (socket) {
+    socket.on('open', function () {
+      socket.send(data);
+      socket.close();
+    });
+  });
+  check(res, {
+    'status was 101': (r) => r && r.status === 101,
+  });
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/proxy"
+
+	"github.com/go-chi/chi/v5"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// compareExperimentsHandler returns an HTTP handler function that handles GET requests to compare two Experiments.
+// The handler function reads the namespace, name, and other parameters from the request URL, where other is the name
+// of the baseline Experiment in the same namespace.
+// It calls the proxy.CompareExperiments function to compare the Experiments using the provided client and query agent.
+// If successful, it responds an HTTP 200 status code with a ComparisonResponse in JSON.
+// If an error occurs, it responds an HTTP 500 status code with an ErrorResponse in JSON.
+func compareExperimentsHandler(client client.Client, qa *proxy.QueryAgent) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		namespace := chi.URLParam(r, "namespace")
+		name := chi.URLParam(r, "name")
+		other := chi.URLParam(r, "other")
+
+		resp, err := proxy.CompareExperiments(ctx, client, qa, namespace, name, other)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(proxy.ErrorResponse{Message: err.Error()})
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(resp)
+		}
+	}
+}
@@ -64,6 +64,7 @@ func getRoutes(router *chi.Mux, client client.Client, queryAgent *proxy.QueryAge
 		r.Post("/experiments/{namespace}/{name}", createObjectHandler(client, proxy.ExperimentKind))
 		r.Put("/experiments/{namespace}/{name}", updateObjectHandler(client, proxy.ExperimentKind))
 		r.Delete("/experiments/{namespace}/{name}", deleteObjectHandler(client, proxy.ExperimentKind))
+		r.Get("/experiments/{namespace}/{name}/compare/{other}", compareExperimentsHandler(client, queryAgent))
 
 		r.Get("/costexporters", getObjectListHandler(client, proxy.CostExporterKind))
 		r.Get("/costexporters/{namespace}/{name}", getObjectHandler(client, proxy.CostExporterKind))
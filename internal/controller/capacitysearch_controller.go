@@ -0,0 +1,333 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/utils"
+)
+
+const (
+	capacitySearchDefaultDuration = 5 * time.Minute
+	// capacitySearchDefaultMaxVUsFactor is the default ratio of the maximum number of VUs to the target rate.
+	capacitySearchDefaultMaxVUsFactor = 10
+)
+
+// CapacitySearchReconciler reconciles a CapacitySearch object
+type CapacitySearchReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=capacitysearches,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=capacitysearches/status,verbs=get;update;patch
+//
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=loadpatterns,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=experiments,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.5/pkg/reconcile
+func (r *CapacitySearchReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the requested CapacitySearch
+	capacitySearch := &windtunnelv1alpha1.CapacitySearch{}
+	if err := r.Get(ctx, req.NamespacedName, capacitySearch); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to fetch CapacitySearch")
+		return ctrl.Result{}, err
+	}
+
+	jobStatus := capacitySearch.Status.JobStatus
+	if capacitySearch.Status.JobStatus == "" {
+		capacitySearch.Status.JobStatus = windtunnelv1alpha1.CapacitySearchRunning
+		capacitySearch.Status.StartTime = ptr.To(metav1.Now())
+	}
+
+	if capacitySearch.Status.JobStatus == windtunnelv1alpha1.CapacitySearchRunning {
+		result, err := r.reconcileRunning(ctx, capacitySearch)
+		if err == nil {
+			if err := r.updateStatus(ctx, capacitySearch); err != nil {
+				logger.Error(err, "Cannot update the status")
+				return ctrl.Result{}, err
+			}
+			r.recordTransition(capacitySearch, jobStatus)
+		}
+		return result, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// updateStatus sets the conditions of the CapacitySearch according to its JobStatus, and updates the status.
+func (r *CapacitySearchReconciler) updateStatus(ctx context.Context, capacitySearch *windtunnelv1alpha1.CapacitySearch) error {
+	switch capacitySearch.Status.JobStatus {
+	case windtunnelv1alpha1.CapacitySearchRunning:
+		setProgressingConditions(&capacitySearch.Status.Conditions, capacitySearch.Generation, conditionReason(string(capacitySearch.Status.JobStatus)), "")
+	case windtunnelv1alpha1.CapacitySearchCompleted:
+		setReadyConditions(&capacitySearch.Status.Conditions, capacitySearch.Generation, windtunnelv1alpha1.ReasonCompleted, "")
+	case windtunnelv1alpha1.CapacitySearchFailed:
+		setDegradedConditions(&capacitySearch.Status.Conditions, capacitySearch.Generation, windtunnelv1alpha1.ReasonFailed, capacitySearch.Status.Error)
+	}
+	return r.Status().Update(ctx, capacitySearch)
+}
+
+// recordTransition emits an event if the JobStatus of the CapacitySearch has changed from the previous one.
+func (r *CapacitySearchReconciler) recordTransition(capacitySearch *windtunnelv1alpha1.CapacitySearch, previous windtunnelv1alpha1.CapacitySearchJobStatus) {
+	recordJobStatusTransition(r.Recorder, capacitySearch, string(previous), string(capacitySearch.Status.JobStatus),
+		string(windtunnelv1alpha1.CapacitySearchFailed), capacitySearch.Status.Error,
+	)
+}
+
+// reconcileRunning reconciles the CapacitySearch when it is running.
+// It records the result of the Experiment testing the current target rate once it is completed,
+// and creates the Experiment testing the next target rate, if any.
+func (r *CapacitySearchReconciler) reconcileRunning(ctx context.Context, capacitySearch *windtunnelv1alpha1.CapacitySearch) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Check the Experiment testing the current target rate
+	if capacitySearch.Status.CurrentExperimentName != "" {
+		experiment := &windtunnelv1alpha1.Experiment{}
+		experimentName := types.NamespacedName{
+			Namespace: capacitySearch.Namespace,
+			Name:      capacitySearch.Status.CurrentExperimentName,
+		}
+		if err := r.Get(ctx, experimentName, experiment); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, fmt.Sprintf("Cannot get Experiment \"%s\"", experimentName))
+			return ctrl.Result{}, err
+		} else if err != nil {
+			// The Experiment may not be in the cache yet, or may have been deleted.
+			// Proceed to create it again for the same target rate, which does nothing if it already exists.
+			logger.Info(fmt.Sprintf("Cannot find Experiment \"%s\"", experimentName))
+		} else {
+			switch experiment.Status.JobStatus {
+			case windtunnelv1alpha1.ExperimentCompleted:
+				point := newCapacitySearchPoint(capacitySearch, experiment)
+				logger.Info(fmt.Sprintf("Rate %d tested by Experiment \"%s\": %s", point.Rate, experiment.Name, point.Verdict))
+				capacitySearch.Status.Points = append(capacitySearch.Status.Points, point)
+				if point.Verdict == windtunnelv1alpha1.SLOPassed &&
+					(capacitySearch.Status.MaxPassingRate == nil || point.Rate > *capacitySearch.Status.MaxPassingRate) {
+					capacitySearch.Status.MaxPassingRate = ptr.To(point.Rate)
+				}
+				capacitySearch.Status.CurrentRate = 0
+				capacitySearch.Status.CurrentExperimentName = ""
+			case windtunnelv1alpha1.ExperimentFailed, windtunnelv1alpha1.ExperimentCancelled:
+				logger.Error(nil, fmt.Sprintf("Experiment \"%s\" is %s", experimentName, experiment.Status.JobStatus))
+				capacitySearch.Status.JobStatus = windtunnelv1alpha1.CapacitySearchFailed
+				capacitySearch.Status.Error = fmt.Sprintf("Experiment \"%s\" for rate %d is %s: %s",
+					experimentName, capacitySearch.Status.CurrentRate, experiment.Status.JobStatus, experiment.Status.Error,
+				)
+				return ctrl.Result{}, nil
+			default:
+				// The controller is notified when the Experiment changes
+				return ctrl.Result{}, nil
+			}
+		}
+	}
+
+	// Find the next target rate to test
+	rate, ok := getCapacitySearchNextRate(capacitySearch)
+	if !ok {
+		capacitySearch.Status.JobStatus = windtunnelv1alpha1.CapacitySearchCompleted
+		capacitySearch.Status.CompletionTime = ptr.To(metav1.Now())
+		return ctrl.Result{}, nil
+	}
+	name := utils.GetCapacitySearchExperimentName(capacitySearch.Name, len(capacitySearch.Status.Points))
+
+	// Create the LoadPattern for the target rate
+	loadPattern := newCapacitySearchLoadPattern(capacitySearch, name, rate)
+	if err := ctrl.SetControllerReference(capacitySearch, loadPattern, r.Scheme); err != nil {
+		logger.Error(err, fmt.Sprintf("Cannot set controller reference for LoadPattern for rate %d", rate))
+		capacitySearch.Status.JobStatus = windtunnelv1alpha1.CapacitySearchFailed
+		capacitySearch.Status.Error = fmt.Sprintf("Cannot set controller reference for LoadPattern for rate %d: %s", rate, err)
+		return ctrl.Result{}, nil
+	}
+	if err := r.Create(ctx, loadPattern); client.IgnoreAlreadyExists(err) != nil {
+		logger.Error(err, fmt.Sprintf("Cannot create LoadPattern for rate %d", rate))
+		capacitySearch.Status.JobStatus = windtunnelv1alpha1.CapacitySearchFailed
+		capacitySearch.Status.Error = fmt.Sprintf("Cannot create LoadPattern for rate %d: %s", rate, err)
+		return ctrl.Result{}, nil
+	} else if err == nil {
+		logger.Info(fmt.Sprintf("Created LoadPattern for rate %d", rate))
+		r.Recorder.Event(capacitySearch, corev1.EventTypeNormal, eventReasonCreated,
+			fmt.Sprintf("Created LoadPattern \"%s\" for rate %d", loadPattern.Name, rate),
+		)
+	}
+
+	// Create the Experiment for the target rate
+	experiment := newCapacitySearchExperiment(capacitySearch, name)
+	if err := ctrl.SetControllerReference(capacitySearch, experiment, r.Scheme); err != nil {
+		logger.Error(err, fmt.Sprintf("Cannot set controller reference for Experiment for rate %d", rate))
+		capacitySearch.Status.JobStatus = windtunnelv1alpha1.CapacitySearchFailed
+		capacitySearch.Status.Error = fmt.Sprintf("Cannot set controller reference for Experiment for rate %d: %s", rate, err)
+		return ctrl.Result{}, nil
+	}
+	if err := r.Create(ctx, experiment); client.IgnoreAlreadyExists(err) != nil {
+		logger.Error(err, fmt.Sprintf("Cannot create Experiment for rate %d", rate))
+		capacitySearch.Status.JobStatus = windtunnelv1alpha1.CapacitySearchFailed
+		capacitySearch.Status.Error = fmt.Sprintf("Cannot create Experiment for rate %d: %s", rate, err)
+		return ctrl.Result{}, nil
+	} else if err == nil {
+		logger.Info(fmt.Sprintf("Created Experiment for rate %d", rate))
+		r.Recorder.Event(capacitySearch, corev1.EventTypeNormal, eventReasonCreated,
+			fmt.Sprintf("Created Experiment \"%s\" for rate %d", experiment.Name, rate),
+		)
+	}
+
+	capacitySearch.Status.CurrentRate = rate
+	capacitySearch.Status.CurrentExperimentName = experiment.Name
+	return ctrl.Result{}, nil
+}
+
+// getCapacitySearchNextRate returns the next target rate to test according to the strategy of the CapacitySearch,
+// or false if the search is finished.
+func getCapacitySearchNextRate(capacitySearch *windtunnelv1alpha1.CapacitySearch) (int64, bool) {
+	spec := &capacitySearch.Spec
+	points := capacitySearch.Status.Points
+	if len(points) == 0 {
+		return spec.MinRate, true
+	}
+
+	// Find the highest passing rate and the lowest failing rate, or 0 if none
+	var maxPassingRate, minFailingRate int64
+	for _, point := range points {
+		if point.Verdict == windtunnelv1alpha1.SLOPassed {
+			maxPassingRate = max(maxPassingRate, point.Rate)
+		} else if minFailingRate == 0 || point.Rate < minFailingRate {
+			minFailingRate = point.Rate
+		}
+	}
+
+	switch spec.Strategy {
+	case windtunnelv1alpha1.CapacitySearchStrategyBinary:
+		// Finish if the lowest rate fails or the highest rate passes
+		if maxPassingRate == 0 || maxPassingRate >= spec.MaxRate {
+			return 0, false
+		}
+		if minFailingRate == 0 {
+			return spec.MaxRate, true
+		}
+		if minFailingRate-maxPassingRate <= spec.Step {
+			return 0, false
+		}
+		return maxPassingRate + (minFailingRate-maxPassingRate)/2, true
+
+	default:
+		if minFailingRate != 0 {
+			return 0, false
+		}
+		rate := points[len(points)-1].Rate + spec.Step
+		if rate > spec.MaxRate {
+			return 0, false
+		}
+		return rate, true
+	}
+}
+
+// newCapacitySearchLoadPattern creates the LoadPattern generating the load at the target rate for the CapacitySearch.
+func newCapacitySearchLoadPattern(capacitySearch *windtunnelv1alpha1.CapacitySearch, name string, rate int64) *windtunnelv1alpha1.LoadPattern {
+	duration := capacitySearchDefaultDuration
+	if capacitySearch.Spec.Duration != nil {
+		duration = capacitySearch.Spec.Duration.Duration
+	}
+	preAllocatedVUs := capacitySearch.Spec.PreAllocatedVUs
+	if preAllocatedVUs == 0 {
+		preAllocatedVUs = rate
+	}
+	maxVUs := capacitySearch.Spec.MaxVUs
+	if maxVUs == 0 {
+		maxVUs = rate * capacitySearchDefaultMaxVUsFactor
+	}
+
+	return &windtunnelv1alpha1.LoadPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: capacitySearch.Namespace,
+			Name:      name,
+		},
+		Spec: windtunnelv1alpha1.LoadPatternSpec{
+			Executor:        windtunnelv1alpha1.LoadPatternExecutorConstantArrivalRate,
+			Rate:            rate,
+			TimeUnit:        "1s",
+			Duration:        duration.String(),
+			PreAllocatedVUs: preAllocatedVUs,
+			MaxVUs:          max(maxVUs, preAllocatedVUs),
+		},
+	}
+}
+
+// newCapacitySearchExperiment creates the Experiment testing the target rate for the CapacitySearch,
+// using the LoadPattern of the same name.
+func newCapacitySearchExperiment(capacitySearch *windtunnelv1alpha1.CapacitySearch, name string) *windtunnelv1alpha1.Experiment {
+	return &windtunnelv1alpha1.Experiment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: capacitySearch.Namespace,
+			Name:      name,
+		},
+		Spec: windtunnelv1alpha1.ExperimentSpec{
+			PipelineRef: capacitySearch.Spec.PipelineRef,
+			EndpointSpecs: []windtunnelv1alpha1.EndpointSpec{
+				{
+					EndpointName: capacitySearch.Spec.EndpointName,
+					DataSpec: &windtunnelv1alpha1.DataSpec{
+						DataSetRef: capacitySearch.Spec.DataSetRef,
+					},
+					LoadPatternRef: &corev1.ObjectReference{
+						Namespace: capacitySearch.Namespace,
+						Name:      name,
+					},
+				},
+			},
+			DrainingTime: capacitySearch.Spec.DrainingTime,
+			SLO:          capacitySearch.Spec.SLO.DeepCopy(),
+		},
+	}
+}
+
+// newCapacitySearchPoint creates the result of the target rate tested by the completed Experiment.
+// The target rate fails if the SLO of the Experiment cannot be evaluated.
+func newCapacitySearchPoint(capacitySearch *windtunnelv1alpha1.CapacitySearch, experiment *windtunnelv1alpha1.Experiment) windtunnelv1alpha1.CapacitySearchPoint {
+	point := windtunnelv1alpha1.CapacitySearchPoint{
+		Rate:           capacitySearch.Status.CurrentRate,
+		ExperimentName: experiment.Name,
+		Verdict:        experiment.Status.SLOVerdict,
+	}
+	if point.Verdict == "" {
+		point.Verdict = windtunnelv1alpha1.SLOFailed
+	}
+	for _, summary := range experiment.Status.Summaries {
+		if summary.EndpointName == capacitySearch.Spec.EndpointName {
+			point.AchievedRate = summary.AchievedRate
+			point.LatencyP50 = summary.LatencyP50
+			point.LatencyP95 = summary.LatencyP95
+			point.LatencyP99 = summary.LatencyP99
+		}
+	}
+	return point
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CapacitySearchReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&windtunnelv1alpha1.CapacitySearch{}).
+		Owns(&windtunnelv1alpha1.Experiment{}).
+		Complete(r)
+}
@@ -10,6 +10,9 @@ import (
 	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
 )
 
+// loadPatternDefaultMaxDuration is the default value of the `maxDuration` field of LoadPattern, same as the K6 default.
+const loadPatternDefaultMaxDuration = 10 * time.Minute
+
 // isJobFinished checks if the Job is finished and returns the condition type.
 func isJobFinished(job *kbatch.Job) (bool, kbatch.JobConditionType) {
 	for _, c := range job.Status.Conditions {
@@ -47,8 +50,17 @@ func getPipelineEndpoint(pipeline *windtunnelv1alpha1.Pipeline, endpointName str
 // getPipelineEndpointProtocol returns the protocol used by the PipelineEndpoint.
 // It returns an empty string if no protocol is specified.
 func getPipelineEndpointProtocol(pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint) windtunnelv1alpha1.EndpointProtocol {
-	if pipelineEndpoint.HTTP != nil && pipelineEndpoint.HTTP.URL != "" && pipelineEndpoint.HTTP.Method != "" {
+	switch {
+	case pipelineEndpoint.HTTP != nil && pipelineEndpoint.HTTP.URL != "" && pipelineEndpoint.HTTP.Method != "":
 		return windtunnelv1alpha1.EndpointProtocolHTTP
+	case pipelineEndpoint.GRPC != nil && pipelineEndpoint.GRPC.Address != "" && pipelineEndpoint.GRPC.Method != "":
+		return windtunnelv1alpha1.EndpointProtocolGRPC
+	case pipelineEndpoint.Kafka != nil && len(pipelineEndpoint.Kafka.Brokers) > 0 && pipelineEndpoint.Kafka.Topic != "":
+		return windtunnelv1alpha1.EndpointProtocolKafka
+	case pipelineEndpoint.MQTT != nil && pipelineEndpoint.MQTT.URL != "" && pipelineEndpoint.MQTT.Topic != "":
+		return windtunnelv1alpha1.EndpointProtocolMQTT
+	case pipelineEndpoint.WebSocket != nil && pipelineEndpoint.WebSocket.URL != "":
+		return windtunnelv1alpha1.EndpointProtocolWebSocket
 	}
 	return ""
 }
@@ -68,9 +80,31 @@ func getEndpointSpecDataOption(endpointSpec *windtunnelv1alpha1.EndpointSpec) wi
 	return windtunnelv1alpha1.EndpointDataOptionPlainText
 }
 
-// getLoadPatternDuration calculates the duration of LoadPattern.
+// getLoadPatternDuration calculates the duration of LoadPattern according to its executor.
+// For the executors running a number of iterations, the actual duration is unknown in advance,
+// so the maximum duration is used instead.
 // It returns an error if the duration cannot be parsed.
 func getLoadPatternDuration(loadPattern *windtunnelv1alpha1.LoadPattern) (*metav1.Duration, error) {
+	switch loadPattern.Spec.Executor {
+	case windtunnelv1alpha1.LoadPatternExecutorConstantVUs, windtunnelv1alpha1.LoadPatternExecutorConstantArrivalRate:
+		duration, err := time.ParseDuration(loadPattern.Spec.Duration)
+		if err != nil {
+			return &metav1.Duration{}, err
+		}
+		return &metav1.Duration{Duration: duration}, nil
+
+	case windtunnelv1alpha1.LoadPatternExecutorPerVUIterations, windtunnelv1alpha1.LoadPatternExecutorSharedIterations:
+		if loadPattern.Spec.MaxDuration == "" {
+			return &metav1.Duration{Duration: loadPatternDefaultMaxDuration}, nil
+		}
+		duration, err := time.ParseDuration(loadPattern.Spec.MaxDuration)
+		if err != nil {
+			return &metav1.Duration{}, err
+		}
+		return &metav1.Duration{Duration: duration}, nil
+	}
+
+	// The "ramping-arrival-rate" and "ramping-vus" executors run through the stages
 	duration := time.Duration(0)
 	for _, stage := range loadPattern.Spec.Stages {
 		stageDuration, err := time.ParseDuration(stage.Duration)
@@ -81,3 +115,42 @@ func getLoadPatternDuration(loadPattern *windtunnelv1alpha1.LoadPattern) (*metav
 	}
 	return &metav1.Duration{Duration: duration}, nil
 }
+
+// getLoadPatternTargetRate calculates the average target rate of requests per second of LoadPattern.
+// Only the "ramping-arrival-rate" and "constant-arrival-rate" executors have a target rate,
+// it returns 0 for other executors.
+// It returns an error if the durations cannot be parsed.
+func getLoadPatternTargetRate(loadPattern *windtunnelv1alpha1.LoadPattern) (float64, error) {
+	timeUnit := time.Second
+	if loadPattern.Spec.TimeUnit != "" {
+		var err error
+		timeUnit, err = time.ParseDuration(loadPattern.Spec.TimeUnit)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	switch loadPattern.Spec.Executor {
+	case windtunnelv1alpha1.LoadPatternExecutorConstantArrivalRate:
+		return float64(loadPattern.Spec.Rate) / timeUnit.Seconds(), nil
+
+	case "", windtunnelv1alpha1.LoadPatternExecutorRampingArrivalRate:
+		// The rate changes linearly in each stage, so the number of requests is the area under the stages
+		var requests, seconds float64
+		rate := float64(loadPattern.Spec.StartRate)
+		for _, stage := range loadPattern.Spec.Stages {
+			stageDuration, err := time.ParseDuration(stage.Duration)
+			if err != nil {
+				return 0, err
+			}
+			requests += (rate + float64(stage.Target)) / 2 * stageDuration.Seconds()
+			seconds += stageDuration.Seconds()
+			rate = float64(stage.Target)
+		}
+		if seconds == 0 {
+			return 0, nil
+		}
+		return requests / seconds / timeUnit.Seconds(), nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+)
+
+// setCondition sets a condition observed at the given generation.
+// It returns whether the condition has changed.
+func setCondition(conditions *[]metav1.Condition, generation int64, conditionType string, status metav1.ConditionStatus, reason, message string) bool {
+	return meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setProgressingConditions sets the conditions of a resource whose work is in progress.
+// It returns whether any condition has changed.
+func setProgressingConditions(conditions *[]metav1.Condition, generation int64, reason, message string) bool {
+	changed := setCondition(conditions, generation, windtunnelv1alpha1.ConditionReady, metav1.ConditionFalse, reason, message)
+	changed = setCondition(conditions, generation, windtunnelv1alpha1.ConditionProgressing, metav1.ConditionTrue, reason, message) || changed
+	return setCondition(conditions, generation, windtunnelv1alpha1.ConditionDegraded, metav1.ConditionFalse, reason, "") || changed
+}
+
+// setReadyConditions sets the conditions of a resource that can be used, or whose work has completed successfully.
+// It returns whether any condition has changed.
+func setReadyConditions(conditions *[]metav1.Condition, generation int64, reason, message string) bool {
+	changed := setCondition(conditions, generation, windtunnelv1alpha1.ConditionReady, metav1.ConditionTrue, reason, message)
+	changed = setCondition(conditions, generation, windtunnelv1alpha1.ConditionProgressing, metav1.ConditionFalse, reason, "") || changed
+	return setCondition(conditions, generation, windtunnelv1alpha1.ConditionDegraded, metav1.ConditionFalse, reason, "") || changed
+}
+
+// setDegradedConditions sets the conditions of a resource that has failed or whose spec is invalid.
+// It returns whether any condition has changed.
+func setDegradedConditions(conditions *[]metav1.Condition, generation int64, reason, message string) bool {
+	changed := setCondition(conditions, generation, windtunnelv1alpha1.ConditionReady, metav1.ConditionFalse, reason, message)
+	changed = setCondition(conditions, generation, windtunnelv1alpha1.ConditionProgressing, metav1.ConditionFalse, reason, "") || changed
+	return setCondition(conditions, generation, windtunnelv1alpha1.ConditionDegraded, metav1.ConditionTrue, reason, message) || changed
+}
+
+// setStoppedConditions sets the conditions of a resource whose work has stopped without completing or failing,
+// e.g., being cancelled.
+// It returns whether any condition has changed.
+func setStoppedConditions(conditions *[]metav1.Condition, generation int64, reason, message string) bool {
+	changed := setCondition(conditions, generation, windtunnelv1alpha1.ConditionReady, metav1.ConditionFalse, reason, message)
+	changed = setCondition(conditions, generation, windtunnelv1alpha1.ConditionProgressing, metav1.ConditionFalse, reason, "") || changed
+	return setCondition(conditions, generation, windtunnelv1alpha1.ConditionDegraded, metav1.ConditionFalse, reason, "") || changed
+}
+
+// setReferencesResolved sets the condition of a resource whose referenced objects all exist.
+// It returns whether the condition has changed.
+func setReferencesResolved(conditions *[]metav1.Condition, generation int64) bool {
+	return setCondition(conditions, generation, windtunnelv1alpha1.ConditionReferencesResolved, metav1.ConditionTrue,
+		windtunnelv1alpha1.ReasonResolved, "",
+	)
+}
+
+// setReferenceNotFound sets the condition of a resource whose referenced object does not exist.
+// It returns whether the condition has changed.
+func setReferenceNotFound(conditions *[]metav1.Condition, generation int64, message string) bool {
+	return setCondition(conditions, generation, windtunnelv1alpha1.ConditionReferencesResolved, metav1.ConditionFalse,
+		windtunnelv1alpha1.ReasonReferenceNotFound, message,
+	)
+}
+
+// conditionReason converts a status text, e.g., "Waiting for DataSet" or "In-Use",
+// to the CamelCase form required by the reason of a condition, e.g., "WaitingForDataSet" or "InUse".
+func conditionReason(status string) string {
+	words := strings.FieldsFunc(status, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, "")
+}
+
+// setValidatedConditions sets the conditions of a resource that has no work to do besides being validated.
+// The resource is ready if err is nil, otherwise it is degraded for the given reason.
+// It returns whether any condition has changed.
+func setValidatedConditions(conditions *[]metav1.Condition, generation int64, reason string, err error) bool {
+	if err != nil {
+		return setDegradedConditions(conditions, generation, reason, err.Error())
+	}
+	return setReadyConditions(conditions, generation, windtunnelv1alpha1.ReasonReconciled, "")
+}
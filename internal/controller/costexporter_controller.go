@@ -6,10 +6,12 @@ import (
 	"time"
 
 	kbatch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,7 +23,7 @@ import (
 )
 
 const (
-	costExporterPollingInterval = 5 * time.Second
+	costExporterPollingInterval = 30 * time.Second
 	costExporterInterval        = 8 * time.Hour
 	costExporterRetryInterval   = 5 * time.Minute
 )
@@ -29,7 +31,8 @@ const (
 // CostExporterReconciler reconciles a CostExporter object
 type CostExporterReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=costexporters,verbs=get;list;watch;create;update;patch;delete
@@ -157,10 +160,11 @@ func (r *CostExporterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			return ctrl.Result{}, err
 		} else if err == nil {
 			logger.Info("Created cost exporter Job")
+			r.Recorder.Event(costExporter, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created cost exporter Job \"%s\"", job.Name))
 		}
 
 		costExporter.Status.IsRunning = true
-		if err := r.Status().Update(ctx, costExporter); err != nil {
+		if err := r.updateStatus(ctx, costExporter); err != nil {
 			logger.Error(err, "Cannot update the status")
 			return ctrl.Result{}, err
 		}
@@ -178,6 +182,7 @@ func (r *CostExporterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 		jobFinished, jobConditionType := isJobFinished(job)
 		if jobFinished {
+			result := metricsResultSuccess
 			switch jobConditionType {
 			case kbatch.JobComplete:
 				logger.Info("Cost exporter Job completed")
@@ -186,22 +191,43 @@ func (r *CostExporterReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			case kbatch.JobFailed:
 				logger.Info("Cost exporter Job failed")
 				costExporter.Status.LastFailure = &metav1.Time{Time: time.Now()}
+				result = metricsResultFailure
 			}
 
 			costExporter.Status.IsRunning = false
-			if err := r.Status().Update(ctx, costExporter); err != nil {
+			if err := r.updateStatus(ctx, costExporter); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
+			// Count the run only after the status is updated, so that it is not counted again on conflicts
+			costExporterRunsTotal.WithLabelValues(costExporter.Namespace, result).Inc()
 		}
 	}
 
 	return ctrl.Result{RequeueAfter: costExporterPollingInterval}, nil
 }
 
+// updateStatus sets the conditions of the CostExporter according to its Job runs, and updates the status.
+func (r *CostExporterReconciler) updateStatus(ctx context.Context, costExporter *windtunnelv1alpha1.CostExporter) error {
+	status := &costExporter.Status
+	switch {
+	case status.IsRunning:
+		setProgressingConditions(&status.Conditions, costExporter.Generation, "Running", "")
+	case status.LastFailure != nil && (status.LastSuccess == nil || status.LastFailure.After(status.LastSuccess.Time)):
+		setDegradedConditions(&status.Conditions, costExporter.Generation, windtunnelv1alpha1.ReasonFailed,
+			fmt.Sprintf("Last Job failed at %s, retrying after %s", status.LastFailure.Format(time.RFC3339), costExporterRetryInterval),
+		)
+	case status.LastSuccess != nil:
+		setReadyConditions(&status.Conditions, costExporter.Generation, windtunnelv1alpha1.ReasonCompleted, "")
+	}
+	return r.Status().Update(ctx, costExporter)
+}
+
 // SetupWithManager sets up the controller with the Manager.
+// Changes of the Job trigger the state transitions, polling only serves as a fallback.
 func (r *CostExporterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&windtunnelv1alpha1.CostExporter{}).
+		Owns(&kbatch.Job{}).
 		Complete(r)
 }
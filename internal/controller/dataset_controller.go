@@ -3,8 +3,12 @@ package controller
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
+	"strconv"
+	"strings"
 	"time"
 
 	kbatch "k8s.io/api/batch/v1"
@@ -14,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgo "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,7 +30,7 @@ import (
 )
 
 const (
-	dataSetPollingInterval = 2 * time.Second
+	dataSetPollingInterval = 30 * time.Second
 	dataSetLogsTimeout     = 30 * time.Second
 )
 
@@ -33,6 +38,7 @@ const (
 type DataSetReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 	CGClient *clientgo.Clientset
 }
 
@@ -67,13 +73,22 @@ func (r *DataSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Create or update PVC and Job
 	// dataSet.Generation is used to track the change in dataSet.Spec
 	// Once the spec is created/updated, we create new PVC & Job, delete the old PVC & Job
+	jobStatus := dataSet.Status.JobStatus
 	if dataSet.Generation != dataSet.Status.LastGeneration {
-		return r.reconcileCreatedOrUpdated(ctx, dataSet)
+		result, err := r.reconcileCreatedOrUpdated(ctx, dataSet)
+		if err == nil {
+			r.recordTransition(dataSet, jobStatus)
+		}
+		return result, err
 	}
 
 	// Fetch the current PVC & Job, check the Job status, and update the DataSet status
 	if dataSet.Status.JobStatus == windtunnelv1alpha1.DataSetJobRunning {
-		return r.reconcileRunning(ctx, dataSet)
+		result, err := r.reconcileRunning(ctx, dataSet)
+		if err == nil {
+			r.recordTransition(dataSet, jobStatus)
+		}
+		return result, err
 	}
 
 	// DataSet is not created/updated, and it is not running, no action needed
@@ -91,6 +106,7 @@ func (r *DataSetReconciler) reconcileCreatedOrUpdated(ctx context.Context, dataS
 	dataSet.Status.CompletionTime = nil
 	dataSet.Status.ErrorCount = 0
 	dataSet.Status.Errors = nil
+	dataSet.Status.Seed = nil
 
 	// Get all Schemas
 	schemaMap := make(map[string]*windtunnelv1alpha1.Schema, len(dataSet.Spec.Schemas))
@@ -106,7 +122,8 @@ func (r *DataSetReconciler) reconcileCreatedOrUpdated(ctx context.Context, dataS
 					fmt.Sprintf("Cannot get Schema \"%s\": %s", schemaName, err),
 				},
 			}
-			if err := r.Status().Update(ctx, dataSet); err != nil {
+			setReferenceNotFound(&dataSet.Status.Conditions, dataSet.Generation, fmt.Sprintf("Cannot get Schema \"%s\": %s", schemaName, err))
+			if err := r.updateStatus(ctx, dataSet); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
@@ -115,6 +132,33 @@ func (r *DataSetReconciler) reconcileCreatedOrUpdated(ctx context.Context, dataS
 		schemaMap[schema.Name] = s
 	}
 
+	// Check the references between Schemas, as the Schemas may have changed after the DataSet was validated
+	schemaNames := make([]string, len(dataSet.Spec.Schemas))
+	for i, schema := range dataSet.Spec.Schemas {
+		schemaNames[i] = schema.Name
+	}
+	if _, err := datagen.SortSchemasByDependency(schemaNames, schemaMap); err != nil {
+		logger.Error(err, "Invalid references between Schemas")
+		var referenceErr datagen.ReferenceError
+		if errors.As(err, &referenceErr) {
+			setReferenceNotFound(&dataSet.Status.Conditions, dataSet.Generation, err.Error())
+		}
+		dataSet.Status.JobStatus = windtunnelv1alpha1.DataSetJobFailed
+		dataSet.Status.ErrorCount = 1
+		dataSet.Status.Errors = map[windtunnelv1alpha1.DataSetErrorType][]string{
+			windtunnelv1alpha1.DataSetControllerError: {
+				fmt.Sprintf("Invalid references between Schemas: %s", err),
+			},
+		}
+		if err := r.updateStatus(ctx, dataSet); err != nil {
+			logger.Error(err, "Cannot update the status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	setReferencesResolved(&dataSet.Status.Conditions, dataSet.Generation)
+
 	// Delete the Job from last generation if exists
 	lastJobName := utils.GetDataGeneratorName(dataSet.Name, dataSet.Status.LastGeneration)
 	lastJob := &kbatch.Job{}
@@ -129,6 +173,7 @@ func (r *DataSetReconciler) reconcileCreatedOrUpdated(ctx context.Context, dataS
 			return ctrl.Result{}, err
 		}
 		logger.Info(fmt.Sprintf("Deleted old Job \"%s\"", lastJobName))
+		r.Recorder.Event(dataSet, corev1.EventTypeNormal, eventReasonDeleted, fmt.Sprintf("Deleted old Job \"%s\"", lastJobName))
 	}
 
 	// Delete the PVC from last generation if exists, it will delete the PV as well
@@ -140,6 +185,7 @@ func (r *DataSetReconciler) reconcileCreatedOrUpdated(ctx context.Context, dataS
 			return ctrl.Result{}, err
 		}
 		logger.Info(fmt.Sprintf("Deleted old PVC \"%s\"", lastPVCName))
+		r.Recorder.Event(dataSet, corev1.EventTypeNormal, eventReasonDeleted, fmt.Sprintf("Deleted old PVC \"%s\"", lastPVCName))
 	}
 
 	// Create a new PVC
@@ -154,11 +200,20 @@ func (r *DataSetReconciler) reconcileCreatedOrUpdated(ctx context.Context, dataS
 		return ctrl.Result{}, err
 	} else if err == nil {
 		logger.Info(fmt.Sprintf("Created new PVC \"%s\"", newPVCName))
+		r.Recorder.Event(dataSet, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created new PVC \"%s\"", newPVCName))
+	}
+
+	// Use the seed in the spec, or choose a random one
+	var seed int64
+	if dataSet.Spec.Seed != nil {
+		seed = *dataSet.Spec.Seed
+	} else {
+		seed = rand.Int64()
 	}
 
 	// Create a new Job
 	newJobName := utils.GetDataGeneratorName(dataSet.Name, dataSet.Generation)
-	newJob, err := datagen.CreateJob(newJobName, newPVCName, dataSet, schemaMap)
+	newJob, err := datagen.CreateJob(newJobName, newPVCName, dataSet, schemaMap, seed)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf("Cannot create manifest for new Job \"%s\"", newJobName))
 		return ctrl.Result{}, err
@@ -172,12 +227,14 @@ func (r *DataSetReconciler) reconcileCreatedOrUpdated(ctx context.Context, dataS
 		return ctrl.Result{}, err
 	} else if err == nil {
 		logger.Info(fmt.Sprintf("Created new Job \"%s\"", newJobName))
+		r.Recorder.Event(dataSet, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created new Job \"%s\"", newJobName))
 	}
 
-	// Update the last generation and Job status
+	// Update the last generation, seed, and Job status
 	dataSet.Status.LastGeneration = dataSet.Generation
+	dataSet.Status.Seed = &seed
 	dataSet.Status.JobStatus = windtunnelv1alpha1.DataSetJobRunning
-	if err := r.Status().Update(ctx, dataSet); err != nil {
+	if err := r.updateStatus(ctx, dataSet); err != nil {
 		logger.Error(err, "Cannot update the status")
 		return ctrl.Result{}, err
 	}
@@ -242,12 +299,25 @@ func (r *DataSetReconciler) reconcileRunning(ctx context.Context, dataSet *windt
 		}
 	}
 
-	if err := r.Status().Update(ctx, dataSet); err != nil {
+	if err := r.updateStatus(ctx, dataSet); err != nil {
 		logger.Error(err, "Cannot update the status")
 		return ctrl.Result{}, err
 	}
 
 	if jobFinished {
+		// Update the metrics only after the status is updated, so that the Job is not observed again on conflicts
+		switch jobConditionType {
+		case kbatch.JobComplete:
+			observeJobDuration(dataSetGenerationSeconds, job, dataSet.Namespace, metricsResultSuccess)
+			if bytesWritten, err := r.getJobBytesWritten(ctx, job); err != nil {
+				logger.Error(err, fmt.Sprintf("Cannot get the size of the output of Job \"%s\"", jobName))
+			} else {
+				dataSetGeneratedBytes.WithLabelValues(dataSet.Namespace).Observe(float64(bytesWritten))
+			}
+		case kbatch.JobFailed:
+			observeJobDuration(dataSetGenerationSeconds, job, dataSet.Namespace, metricsResultFailure)
+		}
+
 		// Job is finished, no need to requeue
 		return ctrl.Result{}, nil
 	} else {
@@ -256,6 +326,35 @@ func (r *DataSetReconciler) reconcileRunning(ctx context.Context, dataSet *windt
 	}
 }
 
+// updateStatus sets the conditions of the DataSet according to its JobStatus, and updates the status.
+func (r *DataSetReconciler) updateStatus(ctx context.Context, dataSet *windtunnelv1alpha1.DataSet) error {
+	switch dataSet.Status.JobStatus {
+	case windtunnelv1alpha1.DataSetJobRunning:
+		setProgressingConditions(&dataSet.Status.Conditions, dataSet.Generation, conditionReason(string(dataSet.Status.JobStatus)), "")
+	case windtunnelv1alpha1.DataSetJobSuccess:
+		setReadyConditions(&dataSet.Status.Conditions, dataSet.Generation, windtunnelv1alpha1.ReasonCompleted, "")
+	case windtunnelv1alpha1.DataSetJobFailed:
+		setDegradedConditions(&dataSet.Status.Conditions, dataSet.Generation, windtunnelv1alpha1.ReasonFailed, getDataSetErrorMessage(dataSet))
+	}
+	return r.Status().Update(ctx, dataSet)
+}
+
+// recordTransition emits an event if the JobStatus of the DataSet has changed from the previous one.
+func (r *DataSetReconciler) recordTransition(dataSet *windtunnelv1alpha1.DataSet, previous windtunnelv1alpha1.DataSetJobStatus) {
+	recordJobStatusTransition(r.Recorder, dataSet, string(previous), string(dataSet.Status.JobStatus),
+		string(windtunnelv1alpha1.DataSetJobFailed), getDataSetErrorMessage(dataSet),
+	)
+}
+
+// getDataSetErrorMessage summarizes the errors of the DataSet.
+// Errors of the controller are short, while errors of the Job are only counted, as they can be many.
+func getDataSetErrorMessage(dataSet *windtunnelv1alpha1.DataSet) string {
+	if controllerErrors := dataSet.Status.Errors[windtunnelv1alpha1.DataSetControllerError]; len(controllerErrors) != 0 {
+		return strings.Join(controllerErrors, "; ")
+	}
+	return fmt.Sprintf("%d error(s) occurred in the data generator job", dataSet.Status.ErrorCount)
+}
+
 // getContainerLogs gets the logs of a container in a Pod.
 func (r *DataSetReconciler) getContainerLogs(ctx context.Context, pod *corev1.Pod, containerName string) (string, error) {
 	// Open a stream for the Pod logs
@@ -322,9 +421,40 @@ func (r *DataSetReconciler) getJobLogs(ctx context.Context, job *kbatch.Job) ([]
 	return result, nil
 }
 
+// getJobBytesWritten gets the total size of the output of all Pods in a Job,
+// which is reported by the data generator in the termination message of its container.
+func (r *DataSetReconciler) getJobBytesWritten(ctx context.Context, job *kbatch.Job) (int64, error) {
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return 0, fmt.Errorf("failed to list Pods: %w", err)
+	}
+
+	var result int64
+	for _, pod := range podList.Items {
+		// Skip if the Pod does not belong to the Job or has not succeeded
+		if !metav1.IsControlledBy(&pod, job) || pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name != datagen.ContainerName || containerStatus.State.Terminated == nil {
+				continue
+			}
+			bytesWritten, err := strconv.ParseInt(strings.TrimSpace(containerStatus.State.Terminated.Message), 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("failed to parse termination message of Pod \"%s\": %w", pod.Name, err)
+			}
+			result += bytesWritten
+		}
+	}
+	return result, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
+// Changes of the PVC and Job trigger the state transitions, polling only serves as a fallback.
 func (r *DataSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&windtunnelv1alpha1.DataSet{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&kbatch.Job{}).
 		Complete(r)
 }
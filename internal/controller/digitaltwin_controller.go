@@ -10,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -19,14 +20,15 @@ import (
 )
 
 const (
-	digitalTwinPollingInterval    = 5 * time.Second
+	digitalTwinPollingInterval    = 30 * time.Second
 	digitalTwinExperimentDuration = 600 // Seconds
 )
 
 // DigitalTwinReconciler reconciles a DigitalTwin object
 type DigitalTwinReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=digitaltwins,verbs=get;list;watch;create;update;patch;delete
@@ -55,13 +57,15 @@ func (r *DigitalTwinReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	jobStatus := digitalTwin.Status.JobStatus
 	if digitalTwin.Status.JobStatus == "" {
 		result, err := r.reconcileCreated(ctx, digitalTwin)
 		if err == nil {
-			if err := r.Status().Update(ctx, digitalTwin); err != nil {
+			if err := r.updateStatus(ctx, digitalTwin); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
+			r.recordTransition(digitalTwin, jobStatus)
 		}
 		return result, err
 	}
@@ -69,10 +73,11 @@ func (r *DigitalTwinReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if digitalTwin.Status.JobStatus == windtunnelv1alpha1.DigitalTwinRunning {
 		result, err := r.reconcileRunning(ctx, digitalTwin)
 		if err == nil {
-			if err := r.Status().Update(ctx, digitalTwin); err != nil {
+			if err := r.updateStatus(ctx, digitalTwin); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
+			r.recordTransition(digitalTwin, jobStatus)
 		}
 		return result, err
 	}
@@ -80,6 +85,26 @@ func (r *DigitalTwinReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
+// updateStatus sets the conditions of the DigitalTwin according to its JobStatus, and updates the status.
+func (r *DigitalTwinReconciler) updateStatus(ctx context.Context, digitalTwin *windtunnelv1alpha1.DigitalTwin) error {
+	switch digitalTwin.Status.JobStatus {
+	case windtunnelv1alpha1.DigitalTwinRunning:
+		setProgressingConditions(&digitalTwin.Status.Conditions, digitalTwin.Generation, conditionReason(string(digitalTwin.Status.JobStatus)), "")
+	case windtunnelv1alpha1.DigitalTwinCompleted:
+		setReadyConditions(&digitalTwin.Status.Conditions, digitalTwin.Generation, windtunnelv1alpha1.ReasonCompleted, "")
+	case windtunnelv1alpha1.DigitalTwinFailed:
+		setDegradedConditions(&digitalTwin.Status.Conditions, digitalTwin.Generation, windtunnelv1alpha1.ReasonFailed, digitalTwin.Status.Error)
+	}
+	return r.Status().Update(ctx, digitalTwin)
+}
+
+// recordTransition emits an event if the JobStatus of the DigitalTwin has changed from the previous one.
+func (r *DigitalTwinReconciler) recordTransition(digitalTwin *windtunnelv1alpha1.DigitalTwin, previous windtunnelv1alpha1.DigitalTwinJobStatus) {
+	recordJobStatusTransition(r.Recorder, digitalTwin, string(previous), string(digitalTwin.Status.JobStatus),
+		string(windtunnelv1alpha1.DigitalTwinFailed), digitalTwin.Status.Error,
+	)
+}
+
 // reconcileCreated reconciles the DigitalTwin when it is created.
 func (r *DigitalTwinReconciler) reconcileCreated(ctx context.Context, digitalTwin *windtunnelv1alpha1.DigitalTwin) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -100,8 +125,10 @@ func (r *DigitalTwinReconciler) reconcileCreated(ctx context.Context, digitalTwi
 			logger.Error(err, fmt.Sprintf("Cannot get DataSet \"%s\"", dataSetName))
 			digitalTwin.Status.JobStatus = windtunnelv1alpha1.DigitalTwinFailed
 			digitalTwin.Status.Error = fmt.Sprintf("Cannot find DataSet \"%s\": %s", dataSetName, err)
+			setReferenceNotFound(&digitalTwin.Status.Conditions, digitalTwin.Generation, digitalTwin.Status.Error)
 			return ctrl.Result{}, nil
 		}
+		setReferencesResolved(&digitalTwin.Status.Conditions, digitalTwin.Generation)
 
 		// Create DataSets
 		for schemaIdx, schemaSelector := range dataSet.Spec.Schemas {
@@ -137,6 +164,9 @@ func (r *DigitalTwinReconciler) reconcileCreated(ctx context.Context, digitalTwi
 				return ctrl.Result{}, nil
 			} else if err == nil {
 				logger.Info(fmt.Sprintf("Created bias DataSet for Schema \"%s\"", schemaSelector.Name))
+				r.Recorder.Event(digitalTwin, corev1.EventTypeNormal, eventReasonCreated,
+					fmt.Sprintf("Created bias DataSet \"%s\" for Schema \"%s\"", biasDataSet.Name, schemaSelector.Name),
+				)
 			}
 		}
 
@@ -170,6 +200,9 @@ func (r *DigitalTwinReconciler) reconcileCreated(ctx context.Context, digitalTwi
 			digitalTwin.Status.JobStatus = windtunnelv1alpha1.DigitalTwinFailed
 			digitalTwin.Status.Error = fmt.Sprintf("Cannot create LoadPattern: %s", err)
 			return ctrl.Result{}, nil
+		} else if err == nil {
+			logger.Info("Created LoadPattern")
+			r.Recorder.Event(digitalTwin, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created LoadPattern \"%s\"", loadPattern.Name))
 		}
 
 		// Create Experiments
@@ -211,6 +244,9 @@ func (r *DigitalTwinReconciler) reconcileCreated(ctx context.Context, digitalTwi
 				return ctrl.Result{}, nil
 			} else if err == nil {
 				logger.Info(fmt.Sprintf("Created bias Experiment for Schema \"%s\"", schemaSelector.Name))
+				r.Recorder.Event(digitalTwin, corev1.EventTypeNormal, eventReasonCreated,
+					fmt.Sprintf("Created bias Experiment \"%s\" for Schema \"%s\"", biasExperiment.Name, schemaSelector.Name),
+				)
 			}
 		}
 
@@ -241,8 +277,10 @@ func (r *DigitalTwinReconciler) reconcileRunning(ctx context.Context, digitalTwi
 			logger.Error(err, fmt.Sprintf("Cannot get DataSet \"%s\"", dataSetName))
 			digitalTwin.Status.JobStatus = windtunnelv1alpha1.DigitalTwinFailed
 			digitalTwin.Status.Error = fmt.Sprintf("Cannot find DataSet \"%s\": %s", dataSetName, err)
+			setReferenceNotFound(&digitalTwin.Status.Conditions, digitalTwin.Generation, digitalTwin.Status.Error)
 			return ctrl.Result{}, nil
 		}
+		setReferencesResolved(&digitalTwin.Status.Conditions, digitalTwin.Generation)
 
 		// Check if any Experiment is completed or failed
 		for schemaIdx, _ := range dataSet.Spec.Schemas {
@@ -265,6 +303,11 @@ func (r *DigitalTwinReconciler) reconcileRunning(ctx context.Context, digitalTwi
 				digitalTwin.Status.JobStatus = windtunnelv1alpha1.DigitalTwinFailed
 				digitalTwin.Status.Error = fmt.Sprintf("Experiment \"%s\" failed", biasExperimentName)
 				return ctrl.Result{}, nil
+			} else if biasExperiment.Status.JobStatus == windtunnelv1alpha1.ExperimentCancelled {
+				logger.Info(fmt.Sprintf("Bias Experiment \"%s\" cancelled", biasExperimentName))
+				digitalTwin.Status.JobStatus = windtunnelv1alpha1.DigitalTwinFailed
+				digitalTwin.Status.Error = fmt.Sprintf("Experiment \"%s\" cancelled", biasExperimentName)
+				return ctrl.Result{}, nil
 			} else {
 				return ctrl.Result{RequeueAfter: digitalTwinPollingInterval}, nil
 			}
@@ -280,8 +323,10 @@ func (r *DigitalTwinReconciler) reconcileRunning(ctx context.Context, digitalTwi
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// Changes of the bias Experiments trigger the state transitions, polling only serves as a fallback.
 func (r *DigitalTwinReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&windtunnelv1alpha1.DigitalTwin{}).
+		Owns(&windtunnelv1alpha1.Experiment{}).
 		Complete(r)
 }
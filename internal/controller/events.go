@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reasons of the events about the child resources created, updated, or deleted by the reconcilers.
+const (
+	eventReasonCreated = "Created"
+	eventReasonUpdated = "Updated"
+	eventReasonDeleted = "Deleted"
+)
+
+// eventReasonAnalysisFailed is the reason of the events about failing to query the results of an Experiment.
+const eventReasonAnalysisFailed = "AnalysisFailed"
+
+// recordJobStatusTransition emits an event for the transition of the JobStatus of a resource, if it has changed
+// to a non-empty status.
+// The reason of the event is the new status in CamelCase, the same as the reason of its Progressing condition.
+// A transition to the failed status emits a warning with the error message, others emit a normal event.
+func recordJobStatusTransition(recorder record.EventRecorder, obj runtime.Object, oldStatus, newStatus, failedStatus, errMessage string) {
+	if oldStatus == newStatus || newStatus == "" {
+		return
+	}
+
+	var message string
+	if oldStatus == "" {
+		message = fmt.Sprintf("Status set to \"%s\"", newStatus)
+	} else {
+		message = fmt.Sprintf("Status changed from \"%s\" to \"%s\"", oldStatus, newStatus)
+	}
+	if newStatus == failedStatus {
+		recorder.Event(obj, corev1.EventTypeWarning, conditionReason(newStatus), fmt.Sprintf("%s: %s", message, errMessage))
+	} else {
+		recorder.Event(obj, corev1.EventTypeNormal, conditionReason(newStatus), message)
+	}
+}
@@ -1,8 +1,11 @@
 package controller
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	k6v1alpha1 "github.com/grafana/k6-operator/api/v1alpha1"
@@ -12,13 +15,18 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/analysis"
 	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/config"
 	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/digitaltwin"
 	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/loadgen"
@@ -27,10 +35,17 @@ import (
 
 const (
 	experimentFinalizerName         = "experiment.windtunnel.plantd.org/finalizer"
-	experimentPollingInterval       = 5 * time.Second
+	experimentPollingInterval       = 30 * time.Second
 	experimentEndDetectorDebounce   = 30 // Seconds
 	experimentEndDetectorWindow     = 90 // Seconds
 	experimentEndDetectorAdjustment = 60 // Seconds
+	// experimentPVCPendingTimeout is the time after which a PVC still pending is reported in the conditions.
+	experimentPVCPendingTimeout = 2 * time.Minute
+
+	// experimentPipelineIndexKey indexes Experiments by the name of their Pipeline.
+	experimentPipelineIndexKey = ".spec.pipelineRef.name"
+	// experimentDataSetIndexKey indexes Experiments by the names of the DataSets used by their endpoints.
+	experimentDataSetIndexKey = ".spec.endpointSpecs.dataSpec.dataSetRef.name"
 )
 
 var (
@@ -41,7 +56,8 @@ var (
 // ExperimentReconciler reconciles a Experiment object
 type ExperimentReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // ExperimentReconcilerContext contains the context for the ExperimentReconciler
@@ -52,6 +68,10 @@ type ExperimentReconcilerContext struct {
 	EndpointDataOptions  map[string]windtunnelv1alpha1.EndpointDataOption
 	EndpointDataSets     map[string]*windtunnelv1alpha1.DataSet
 	EndpointLoadPatterns map[string]*windtunnelv1alpha1.LoadPattern
+	// Message of the Progressing condition explaining why the Experiment is not making progress, if any
+	ProgressingMessage string
+	// Metrics to observe once the status is updated, so that they are not observed again on conflicts
+	PendingMetrics []func()
 }
 
 // NewExperimentReconcilerContext creates a new ExperimentReconcilerContext
@@ -118,7 +138,7 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			if err := r.Get(ctx, pipelineName, pipeline); err != nil {
 				logger.Error(err, fmt.Sprintf("Lost Pipeline \"%s\"", pipelineName))
 			} else {
-				if err := r.releasePipeline(ctx, pipeline); err != nil {
+				if err := r.releasePipeline(ctx, experiment, pipeline); err != nil {
 					return ctrl.Result{}, err
 				}
 			}
@@ -134,16 +154,30 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	// No need to reconcile if the Experiment is completed or failed
-	if experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentCompleted || experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentFailed {
+	// No need to reconcile if the Experiment is completed, failed, or cancelled
+	if experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentCompleted || experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentFailed ||
+		experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentCancelled {
 		return ctrl.Result{}, nil
 	}
 
 	// Initiate the reconciler context
 	rc := NewExperimentReconcilerContext()
+
+	jobStatus := experiment.Status.JobStatus
+	if experiment.Spec.Cancel {
+		_, result, err := r.reconcileCancelling(ctx, experiment)
+		r.recordTransition(experiment, jobStatus)
+		if err := r.updateStatus(ctx, experiment, rc); err != nil {
+			logger.Error(err, "Cannot update the status")
+			return ctrl.Result{}, err
+		}
+		return result, err
+	}
+
 	stop, result, err := r.getRelatedResources(ctx, experiment, rc)
+	jobStatus = r.recordTransition(experiment, jobStatus)
 	if stop {
-		if err := r.Status().Update(ctx, experiment); err != nil {
+		if err := r.updateStatus(ctx, experiment, rc); err != nil {
 			logger.Error(err, "Cannot update the status")
 			return ctrl.Result{}, err
 		}
@@ -152,8 +186,9 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if experiment.Status.JobStatus == "" {
 		stop, result, err := r.reconcileCreated(ctx, experiment, rc)
+		jobStatus = r.recordTransition(experiment, jobStatus)
 		if stop {
-			if err := r.Status().Update(ctx, experiment); err != nil {
+			if err := r.updateStatus(ctx, experiment, rc); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
@@ -163,8 +198,9 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentScheduled {
 		stop, result, err := r.reconciledScheduled(ctx, experiment, rc)
+		jobStatus = r.recordTransition(experiment, jobStatus)
 		if stop {
-			if err := r.Status().Update(ctx, experiment); err != nil {
+			if err := r.updateStatus(ctx, experiment, rc); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
@@ -174,8 +210,9 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentWaitingDataSet {
 		stop, result, err := r.reconcileWaitingDataSet(ctx, experiment, rc)
+		jobStatus = r.recordTransition(experiment, jobStatus)
 		if stop {
-			if err := r.Status().Update(ctx, experiment); err != nil {
+			if err := r.updateStatus(ctx, experiment, rc); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
@@ -185,8 +222,9 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentWaitingPipeline {
 		stop, result, err := r.reconcileWaitingPipeline(ctx, experiment, rc)
+		jobStatus = r.recordTransition(experiment, jobStatus)
 		if stop {
-			if err := r.Status().Update(ctx, experiment); err != nil {
+			if err := r.updateStatus(ctx, experiment, rc); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
@@ -196,8 +234,9 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentInitializing {
 		stop, result, err := r.reconcileInitializing(ctx, experiment, rc)
+		jobStatus = r.recordTransition(experiment, jobStatus)
 		if stop {
-			if err := r.Status().Update(ctx, experiment); err != nil {
+			if err := r.updateStatus(ctx, experiment, rc); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
@@ -207,8 +246,9 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentRunning {
 		stop, result, err := r.reconcileRunning(ctx, experiment, rc)
+		jobStatus = r.recordTransition(experiment, jobStatus)
 		if stop {
-			if err := r.Status().Update(ctx, experiment); err != nil {
+			if err := r.updateStatus(ctx, experiment, rc); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
@@ -218,8 +258,9 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if experiment.Status.JobStatus == windtunnelv1alpha1.ExperimentDraining {
 		stop, result, err := r.reconcileDraining(ctx, experiment, rc)
+		jobStatus = r.recordTransition(experiment, jobStatus)
 		if stop {
-			if err := r.Status().Update(ctx, experiment); err != nil {
+			if err := r.updateStatus(ctx, experiment, rc); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
@@ -230,6 +271,39 @@ func (r *ExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
+// updateStatus sets the conditions of the Experiment according to its JobStatus, and updates the status.
+// The message is set to the Progressing condition if the Experiment is in progress.
+func (r *ExperimentReconciler) updateStatus(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, rc *ExperimentReconcilerContext) error {
+	switch experiment.Status.JobStatus {
+	case "":
+	case windtunnelv1alpha1.ExperimentCompleted:
+		setReadyConditions(&experiment.Status.Conditions, experiment.Generation, windtunnelv1alpha1.ReasonCompleted, "")
+	case windtunnelv1alpha1.ExperimentFailed:
+		setDegradedConditions(&experiment.Status.Conditions, experiment.Generation, windtunnelv1alpha1.ReasonFailed, experiment.Status.Error)
+	case windtunnelv1alpha1.ExperimentCancelled:
+		setStoppedConditions(&experiment.Status.Conditions, experiment.Generation, windtunnelv1alpha1.ReasonCancelled, "")
+	default:
+		setProgressingConditions(&experiment.Status.Conditions, experiment.Generation, conditionReason(string(experiment.Status.JobStatus)), rc.ProgressingMessage)
+	}
+	if err := r.Status().Update(ctx, experiment); err != nil {
+		return err
+	}
+	for _, observe := range rc.PendingMetrics {
+		observe()
+	}
+	rc.PendingMetrics = nil
+	return nil
+}
+
+// recordTransition emits an event if the JobStatus of the Experiment has changed from the previous one,
+// and returns the current JobStatus.
+func (r *ExperimentReconciler) recordTransition(experiment *windtunnelv1alpha1.Experiment, previous windtunnelv1alpha1.ExperimentJobStatus) windtunnelv1alpha1.ExperimentJobStatus {
+	recordJobStatusTransition(r.Recorder, experiment, string(previous), string(experiment.Status.JobStatus),
+		string(windtunnelv1alpha1.ExperimentFailed), experiment.Status.Error,
+	)
+	return experiment.Status.JobStatus
+}
+
 // getRelatedResources gets the related resources used by the Experiment and update the reconciler context.
 // It returns a flag of whether the current reconciliation loop should stop,
 // the reconciliation result, and an error, if any.
@@ -246,6 +320,7 @@ func (r *ExperimentReconciler) getRelatedResources(ctx context.Context, experime
 		logger.Error(err, fmt.Sprintf("Cannot get Pipeline \"%s\"", pipelineName))
 		experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentFailed
 		experiment.Status.Error = fmt.Sprintf("Cannot find Pipeline \"%s\": %s", pipelineName, err)
+		setReferenceNotFound(&experiment.Status.Conditions, experiment.Generation, experiment.Status.Error)
 		return true, ctrl.Result{}, nil
 	}
 	rc.Pipeline = pipeline
@@ -296,6 +371,7 @@ func (r *ExperimentReconciler) getRelatedResources(ctx context.Context, experime
 				experiment.Status.Error = fmt.Sprintf("Cannot find DataSet \"%s\" for endpoint \"%s\": %s",
 					dataSetName, endpointSpec.EndpointName, err,
 				)
+				setReferenceNotFound(&experiment.Status.Conditions, experiment.Generation, experiment.Status.Error)
 				return true, ctrl.Result{}, nil
 			}
 			rc.EndpointDataSets[endpointSpec.EndpointName] = dataSet
@@ -315,10 +391,12 @@ func (r *ExperimentReconciler) getRelatedResources(ctx context.Context, experime
 			experiment.Status.Error = fmt.Sprintf("Cannot find LoadPattern \"%s\" for endpoint \"%s\": %s",
 				loadPatternName, endpointSpec.EndpointName, err,
 			)
+			setReferenceNotFound(&experiment.Status.Conditions, experiment.Generation, experiment.Status.Error)
 			return true, ctrl.Result{}, nil
 		}
 		rc.EndpointLoadPatterns[endpointSpec.EndpointName] = loadPattern
 	}
+	setReferencesResolved(&experiment.Status.Conditions, experiment.Generation)
 
 	// Proceed
 	return false, ctrl.Result{}, nil
@@ -386,6 +464,7 @@ func (r *ExperimentReconciler) reconcileWaitingDataSet(ctx context.Context, expe
 
 	// Proceed to the next state
 	experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentWaitingPipeline
+	experiment.Status.WaitingPipelineStartTime = ptr.To(metav1.Now())
 	return false, ctrl.Result{}, nil
 }
 
@@ -395,18 +474,38 @@ func (r *ExperimentReconciler) reconcileWaitingDataSet(ctx context.Context, expe
 func (r *ExperimentReconciler) reconcileWaitingPipeline(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, rc *ExperimentReconcilerContext) (bool, ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// Check if the Pipeline is in "Ready" status
-	if rc.Pipeline.Status.Availability != windtunnelv1alpha1.PipelineReady {
+	// Find the position of the Experiment in the queue of the Pipeline
+	queue, err := r.getPipelineQueue(ctx, experiment, rc.Pipeline)
+	if err != nil {
+		logger.Error(err, "Cannot get the queue of the Pipeline")
+		return true, ctrl.Result{}, err
+	}
+	position := slices.Index(queue, experiment.Name) + 1
+	experiment.Status.QueuePosition = int32(position)
+
+	// Wait if the Pipeline is not in "Ready" status, or other Experiments are ahead in the queue
+	if rc.Pipeline.Status.Availability != windtunnelv1alpha1.PipelineReady || position > 1 {
+		if !slices.Equal(rc.Pipeline.Status.Queue, queue) {
+			rc.Pipeline.Status.Queue = queue
+			if err := r.Status().Update(ctx, rc.Pipeline); err != nil {
+				logger.Error(err, "Cannot update the queue of the Pipeline")
+				return true, ctrl.Result{}, err
+			}
+		}
 		return true, ctrl.Result{RequeueAfter: experimentPollingInterval}, nil
 	}
 
-	// Lock the Pipeline by setting its status to "In-Use"
+	// Lock the Pipeline by setting its status to "In-Use", and remove the Experiment from the queue.
+	// The update fails if the Pipeline has been changed since it was fetched, e.g., locked by another Experiment.
 	rc.Pipeline.Status.Availability = windtunnelv1alpha1.PipelineInUse
+	rc.Pipeline.Status.Holder = experiment.Name
+	rc.Pipeline.Status.Queue = queue[1:]
 	if err := r.Status().Update(ctx, rc.Pipeline); err != nil {
 		logger.Error(err, "Cannot update the status of the Pipeline")
 		return true, ctrl.Result{}, err
 	}
 	logger.Info("Set the Pipeline status to \"In-Use\"")
+	experiment.Status.QueuePosition = 0
 
 	// Set the Experiment label for the metrics Service
 	if containMetricsEndpoint(rc.Pipeline) {
@@ -441,6 +540,12 @@ func (r *ExperimentReconciler) reconcileWaitingPipeline(ctx context.Context, exp
 	}
 
 	// Proceed to the next state
+	if experiment.Status.WaitingPipelineStartTime != nil {
+		waitingSeconds := time.Since(experiment.Status.WaitingPipelineStartTime.Time).Seconds()
+		rc.PendingMetrics = append(rc.PendingMetrics, func() {
+			experimentWaitingPipelineSeconds.WithLabelValues(experiment.Namespace).Observe(waitingSeconds)
+		})
+	}
 	experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentInitializing
 	return false, ctrl.Result{}, nil
 }
@@ -473,6 +578,7 @@ func (r *ExperimentReconciler) reconcileInitializing(ctx context.Context, experi
 				rc.Endpoints[endpointSpec.EndpointName],
 				endpointSpec.DataSpec.PlainText,
 				rc.EndpointLoadPatterns[endpointSpec.EndpointName],
+				loadgen.GetParallelism(&endpointSpec),
 				rc.EndpointProtocols[endpointSpec.EndpointName],
 			)
 			if err != nil {
@@ -492,6 +598,9 @@ func (r *ExperimentReconciler) reconcileInitializing(ctx context.Context, experi
 				return true, ctrl.Result{}, err
 			} else if err == nil {
 				logger.Info(fmt.Sprintf("Created ConfigMap for endpoint \"%s\"", endpointSpec.EndpointName))
+				r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created ConfigMap \"%s\" for endpoint \"%s\"",
+					configMap.Name, endpointSpec.EndpointName,
+				))
 			}
 
 			doneCounter++
@@ -504,6 +613,7 @@ func (r *ExperimentReconciler) reconcileInitializing(ctx context.Context, experi
 				rc.Endpoints[endpointSpec.EndpointName],
 				rc.EndpointDataSets[endpointSpec.EndpointName],
 				rc.EndpointLoadPatterns[endpointSpec.EndpointName],
+				loadgen.GetParallelism(&endpointSpec),
 				rc.EndpointProtocols[endpointSpec.EndpointName],
 			)
 			if err != nil {
@@ -523,6 +633,9 @@ func (r *ExperimentReconciler) reconcileInitializing(ctx context.Context, experi
 				return true, ctrl.Result{}, err
 			} else if err == nil {
 				logger.Info(fmt.Sprintf("Created ConfigMap for endpoint \"%s\"", endpointSpec.EndpointName))
+				r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created ConfigMap \"%s\" for endpoint \"%s\"",
+					configMap.Name, endpointSpec.EndpointName,
+				))
 			}
 
 			// PVC
@@ -533,11 +646,38 @@ func (r *ExperimentReconciler) reconcileInitializing(ctx context.Context, experi
 				))
 				return true, ctrl.Result{}, err
 			}
+			pvcCreated := false
 			if err := r.Create(ctx, pvc); client.IgnoreAlreadyExists(err) != nil {
 				logger.Error(err, fmt.Sprintf("Cannot create PVC for endpoint \"%s\"", endpointSpec.EndpointName))
 				return true, ctrl.Result{}, err
 			} else if err == nil {
 				logger.Info(fmt.Sprintf("Created PVC for endpoint \"%s\"", endpointSpec.EndpointName))
+				r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created PVC \"%s\" for endpoint \"%s\"",
+					pvc.Name, endpointSpec.EndpointName,
+				))
+				pvcCreated = true
+			}
+
+			// Report the PVC if it cannot be bound, e.g., the StorageClass does not support the access mode.
+			// A PVC created in this pass may not be in the cache yet, and cannot have been pending for long anyway.
+			if !pvcCreated {
+				pvcName := types.NamespacedName{
+					Namespace: pvc.Namespace,
+					Name:      pvc.Name,
+				}
+				if err := r.Get(ctx, pvcName, pvc); client.IgnoreNotFound(err) != nil {
+					logger.Error(err, fmt.Sprintf("Cannot get PVC \"%s\" for endpoint \"%s\"", pvcName, endpointSpec.EndpointName))
+					return true, ctrl.Result{}, err
+				} else if err == nil && pvc.Status.Phase == corev1.ClaimPending && time.Since(pvc.CreationTimestamp.Time) > experimentPVCPendingTimeout {
+					var accessMode corev1.PersistentVolumeAccessMode
+					if len(pvc.Spec.AccessModes) > 0 {
+						accessMode = pvc.Spec.AccessModes[0]
+					}
+					rc.ProgressingMessage = fmt.Sprintf("PVC \"%s\" for endpoint \"%s\" is pending for over %s, check if the StorageClass supports the access mode \"%s\", or set \"storageClassName\" or \"storageAccessMode\" of the endpoint",
+						pvcName, endpointSpec.EndpointName, experimentPVCPendingTimeout, accessMode,
+					)
+					logger.Info(rc.ProgressingMessage)
+				}
 			}
 
 			// Copier Job
@@ -582,6 +722,9 @@ func (r *ExperimentReconciler) reconcileInitializing(ctx context.Context, experi
 				return true, ctrl.Result{}, err
 			} else if err == nil {
 				logger.Info(fmt.Sprintf("Created copier Job for endpoint \"%s\"", endpointSpec.EndpointName))
+				r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created copier Job \"%s\" for endpoint \"%s\"",
+					copierJob.Name, endpointSpec.EndpointName,
+				))
 			}
 		}
 	}
@@ -591,7 +734,7 @@ func (r *ExperimentReconciler) reconcileInitializing(ctx context.Context, experi
 
 	// Create TestRun for each endpoint
 	for endpointIdx, endpointSpec := range experiment.Spec.EndpointSpecs {
-		testRun := loadgen.CreateTestRun(experiment, endpointIdx, &endpointSpec)
+		testRun := loadgen.CreateTestRun(experiment, endpointIdx, &endpointSpec, rc.Endpoints[endpointSpec.EndpointName])
 		switch rc.EndpointDataOptions[endpointSpec.EndpointName] {
 		case windtunnelv1alpha1.EndpointDataOptionPlainText:
 			testRun.Spec.Script = k6v1alpha1.K6Script{
@@ -618,8 +761,11 @@ func (r *ExperimentReconciler) reconcileInitializing(ctx context.Context, experi
 		if err := r.Create(ctx, testRun); client.IgnoreAlreadyExists(err) != nil {
 			logger.Error(err, fmt.Sprintf("Cannot create TestRun for endpoint \"%s\"", endpointSpec.EndpointName))
 			return true, ctrl.Result{}, err
-		} else {
+		} else if err == nil {
 			logger.Info(fmt.Sprintf("Created TestRun for endpoint \"%s\"", endpointSpec.EndpointName))
+			r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created TestRun \"%s\" for endpoint \"%s\"",
+				testRun.Name, endpointSpec.EndpointName,
+			))
 		}
 	}
 
@@ -640,8 +786,9 @@ func (r *ExperimentReconciler) reconcileInitializing(ctx context.Context, experi
 		if err := r.Create(ctx, endDetectorJob); client.IgnoreAlreadyExists(err) != nil {
 			logger.Error(err, "Cannot create end detector Job")
 			return true, ctrl.Result{}, err
-		} else {
+		} else if err == nil {
 			logger.Info("Created end detector Job")
+			r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created end detector Job \"%s\"", endDetectorJob.Name))
 		}
 	}
 
@@ -684,6 +831,236 @@ func (r *ExperimentReconciler) reconcileRunning(ctx context.Context, experiment
 	}
 
 	// Remove the resources created for the Experiment
+	if err := r.deleteLoadGeneratorResources(ctx, experiment); err != nil {
+		return true, ctrl.Result{}, err
+	}
+
+	// Proceed to the next state
+	experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentDraining
+	experiment.Status.DrainingStartTime = ptr.To(metav1.Now())
+	rc.PendingMetrics = append(rc.PendingMetrics, func() {
+		observeExperimentDuration(experiment)
+	})
+	return false, ctrl.Result{}, nil
+}
+
+// observeExperimentDuration observes the duration of the load generation of the Experiment,
+// and its ratio to the longest duration calculated from the LoadPatterns.
+func observeExperimentDuration(experiment *windtunnelv1alpha1.Experiment) {
+	if experiment.Status.StartTime == nil {
+		return
+	}
+	duration := experiment.Status.DrainingStartTime.Sub(experiment.Status.StartTime.Time)
+	experimentDurationSeconds.WithLabelValues(experiment.Namespace).Observe(duration.Seconds())
+
+	var expectedDuration time.Duration
+	for _, endpointDuration := range experiment.Status.Durations {
+		if endpointDuration != nil && endpointDuration.Duration > expectedDuration {
+			expectedDuration = endpointDuration.Duration
+		}
+	}
+	if expectedDuration > 0 {
+		experimentDurationRatio.WithLabelValues(experiment.Namespace).Observe(duration.Seconds() / expectedDuration.Seconds())
+	}
+}
+
+// reconcileDraining reconciles the Experiment when it is draining.
+// It returns a flag of whether the current reconciliation loop should stop,
+// the reconciliation result, and an error, if any.
+func (r *ExperimentReconciler) reconcileDraining(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, rc *ExperimentReconcilerContext) (bool, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	curTime := time.Now()
+
+	// Check if the end detector Job is finished
+	var endDetectorError string
+	if experiment.Spec.UseEndDetection {
+		endDetectorJob := &kbatch.Job{}
+		endDetectorJobName := types.NamespacedName{
+			Namespace: experiment.Namespace,
+			Name:      utils.GetEndDetectorJobName(experiment.Name),
+		}
+		if err := r.Get(ctx, endDetectorJobName, endDetectorJob); err != nil {
+			logger.Error(err, fmt.Sprintf("Lost end detector Job \"%s\"", endDetectorJobName))
+			endDetectorError = fmt.Sprintf("Lost end detector Job \"%s\": %s", endDetectorJobName, err)
+		} else {
+			jobFinished, jobConditionType := isJobFinished(endDetectorJob)
+			if !jobFinished {
+				return true, ctrl.Result{RequeueAfter: experimentPollingInterval}, nil
+			}
+			logger.Info(fmt.Sprintf("End detector Job \"%s\" finished", endDetectorJobName))
+			switch jobConditionType {
+			case kbatch.JobComplete:
+				calculatedCompletionTime := curTime.Add(-experimentEndDetectorAdjustment * time.Second)
+				experiment.Status.CompletionTime = &metav1.Time{Time: calculatedCompletionTime}
+			case kbatch.JobFailed:
+				logger.Error(nil, fmt.Sprintf("End detector Job \"%s\" failed", endDetectorJobName))
+				endDetectorError = fmt.Sprintf("End detector Job \"%s\" failed", endDetectorJobName)
+			}
+		}
+	}
+
+	// Check if the draining time has been fulfilled
+	if !experiment.Spec.UseEndDetection && experiment.Spec.DrainingTime != nil && curTime.Before(experiment.Status.DrainingStartTime.Time.Add(experiment.Spec.DrainingTime.Duration)) {
+		// Time has not been fulfilled, calculate the waiting time
+		waitTime := experiment.Status.DrainingStartTime.Time.Add(experiment.Spec.DrainingTime.Duration).Sub(curTime)
+		logger.Info(fmt.Sprintf("Pipeline is draining, waiting for \"%s\"", waitTime))
+		return true, ctrl.Result{RequeueAfter: waitTime}, nil
+	}
+
+	// Release the Pipeline, which is held until the status changes
+	if err := r.releasePipeline(ctx, experiment, rc.Pipeline); err != nil {
+		return true, ctrl.Result{}, err
+	}
+
+	// Stop the reconciliation loop, keeping the completion time calculated from the end detector.
+	// The results of a failed Experiment are not analyzed.
+	if experiment.Status.CompletionTime == nil {
+		experiment.Status.CompletionTime = ptr.To(metav1.Now())
+	}
+	if endDetectorError != "" {
+		experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentFailed
+		experiment.Status.Error = endDetectorError
+		return true, ctrl.Result{}, nil
+	}
+	experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentCompleted
+	r.analyzeResults(ctx, experiment, rc)
+	return true, ctrl.Result{}, nil
+}
+
+// analyzeResults summarizes the load generated for each endpoint of the completed Experiment, evaluates
+// its SLO, if set, and compares its results with the baseline Experiment, if set.
+// The SLO fails if the metrics cannot be queried.
+func (r *ExperimentReconciler) analyzeResults(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, rc *ExperimentReconcilerContext) {
+	logger := log.FromContext(ctx)
+
+	if experiment.Status.StartTime == nil || experiment.Status.CompletionTime == nil {
+		return
+	}
+
+	endpoints := make([]analysis.Endpoint, 0, len(experiment.Spec.EndpointSpecs))
+	for _, endpointSpec := range experiment.Spec.EndpointSpecs {
+		targetRate, err := getLoadPatternTargetRate(rc.EndpointLoadPatterns[endpointSpec.EndpointName])
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Cannot calculate target rate for endpoint \"%s\"", endpointSpec.EndpointName))
+		}
+		endpoints = append(endpoints, analysis.Endpoint{
+			Name:       endpointSpec.EndpointName,
+			Protocol:   rc.EndpointProtocols[endpointSpec.EndpointName],
+			TargetRate: targetRate,
+		})
+	}
+
+	querier, err := analysis.NewQuerier()
+	if err != nil {
+		logger.Error(err, "Cannot create Prometheus querier")
+		r.Recorder.Event(experiment, corev1.EventTypeWarning, eventReasonAnalysisFailed,
+			fmt.Sprintf("Cannot create Prometheus querier: %s", err),
+		)
+		if experiment.Spec.SLO != nil {
+			experiment.Status.SLOVerdict = windtunnelv1alpha1.SLOFailed
+		}
+		return
+	}
+
+	// Summarize the load generated for each endpoint
+	summaries, err := querier.Summarize(ctx, experiment, endpoints)
+	if err != nil {
+		logger.Error(err, "Cannot summarize results")
+		r.Recorder.Event(experiment, corev1.EventTypeWarning, eventReasonAnalysisFailed,
+			fmt.Sprintf("Cannot summarize results: %s", err),
+		)
+	} else {
+		experiment.Status.Summaries = summaries
+	}
+
+	// Evaluate the SLO
+	if experiment.Spec.SLO != nil && experiment.Status.DrainingStartTime != nil {
+		r.evaluateSLO(ctx, experiment, querier, endpoints)
+	}
+
+	// Compare with the baseline Experiment
+	if experiment.Spec.BaselineRef != nil {
+		r.compareWithBaseline(ctx, experiment, querier)
+	}
+}
+
+// evaluateSLO evaluates the SLO of the completed Experiment, and emits an event about the verdict.
+func (r *ExperimentReconciler) evaluateSLO(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, querier *analysis.Querier, endpoints []analysis.Endpoint) {
+	experiment.Status.SLOVerdict, experiment.Status.SLOResults = querier.EvaluateSLO(ctx, experiment, endpoints)
+	if experiment.Status.SLOVerdict == windtunnelv1alpha1.SLOPassed {
+		r.Recorder.Event(experiment, corev1.EventTypeNormal, string(windtunnelv1alpha1.SLOPassed),
+			fmt.Sprintf("All %d criteria of the SLO are met", len(experiment.Status.SLOResults)),
+		)
+	} else {
+		var failed []string
+		for _, result := range experiment.Status.SLOResults {
+			if !result.Passed && result.EndpointName != "" {
+				failed = append(failed, fmt.Sprintf("%s of endpoint \"%s\"", result.Name, result.EndpointName))
+			} else if !result.Passed {
+				failed = append(failed, result.Name)
+			}
+		}
+		r.Recorder.Event(experiment, corev1.EventTypeWarning, string(windtunnelv1alpha1.SLOFailed),
+			fmt.Sprintf("%d of %d criteria of the SLO are not met: %s",
+				len(failed), len(experiment.Status.SLOResults), strings.Join(failed, ", "),
+			),
+		)
+	}
+}
+
+// compareWithBaseline compares the results of the completed Experiment with those of its baseline Experiment,
+// and emits an event about the verdict. The comparison is skipped if the baseline Experiment is not completed.
+func (r *ExperimentReconciler) compareWithBaseline(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, querier *analysis.Querier) {
+	logger := log.FromContext(ctx)
+
+	baseline := &windtunnelv1alpha1.Experiment{}
+	baselineName := types.NamespacedName{
+		Namespace: experiment.Namespace,
+		Name:      experiment.Spec.BaselineRef.Name,
+	}
+	if err := r.Get(ctx, baselineName, baseline); err != nil {
+		logger.Error(err, fmt.Sprintf("Cannot get baseline Experiment \"%s\"", baselineName))
+		r.Recorder.Event(experiment, corev1.EventTypeWarning, eventReasonAnalysisFailed,
+			fmt.Sprintf("Cannot get baseline Experiment \"%s\": %s", baselineName, err),
+		)
+		return
+	}
+	if baseline.Status.JobStatus != windtunnelv1alpha1.ExperimentCompleted ||
+		baseline.Status.StartTime == nil || baseline.Status.CompletionTime == nil {
+		r.Recorder.Event(experiment, corev1.EventTypeWarning, eventReasonAnalysisFailed,
+			fmt.Sprintf("Baseline Experiment \"%s\" is not completed", baselineName),
+		)
+		return
+	}
+
+	experiment.Status.ComparisonVerdict, experiment.Status.ComparisonResults = querier.Compare(ctx, experiment, baseline, experiment.Spec.RegressionTolerance)
+	if experiment.Status.ComparisonVerdict == windtunnelv1alpha1.ComparisonNoRegression {
+		r.Recorder.Event(experiment, corev1.EventTypeNormal, string(windtunnelv1alpha1.ComparisonNoRegression),
+			fmt.Sprintf("No regression in %d metrics compared with baseline Experiment \"%s\"",
+				len(experiment.Status.ComparisonResults), baselineName,
+			),
+		)
+	} else {
+		var regressed []string
+		for _, result := range experiment.Status.ComparisonResults {
+			if result.Regressed {
+				regressed = append(regressed, fmt.Sprintf("%s of endpoint \"%s\"", result.Name, result.EndpointName))
+			}
+		}
+		r.Recorder.Event(experiment, corev1.EventTypeWarning, string(windtunnelv1alpha1.ComparisonRegressed),
+			fmt.Sprintf("%d of %d metrics regress compared with baseline Experiment \"%s\": %s",
+				len(regressed), len(experiment.Status.ComparisonResults), baselineName, strings.Join(regressed, ", "),
+			),
+		)
+	}
+}
+
+// deleteLoadGeneratorResources deletes the TestRuns and the resources created for them, if they exist.
+// Deleting a TestRun also stops the load generator Pods of it.
+func (r *ExperimentReconciler) deleteLoadGeneratorResources(ctx context.Context, experiment *windtunnelv1alpha1.Experiment) error {
+	logger := log.FromContext(ctx)
+
 	for endpointIdx, endpointSpec := range experiment.Spec.EndpointSpecs {
 		// TestRun
 		testRun := &k6v1alpha1.TestRun{}
@@ -693,181 +1070,245 @@ func (r *ExperimentReconciler) reconcileRunning(ctx context.Context, experiment
 		}
 		if err := r.Get(ctx, testRunName, testRun); client.IgnoreNotFound(err) != nil {
 			logger.Error(err, fmt.Sprintf("Lost TestRun \"%s\" for endpoint \"%s\"", testRunName, endpointSpec.EndpointName))
-			return true, ctrl.Result{}, err
+			return err
 		} else if err == nil {
 			if err := r.Delete(ctx, testRun); err != nil {
 				logger.Error(err, fmt.Sprintf("Cannot delete TestRun \"%s\" for endpoint \"%s\"", testRunName, endpointSpec.EndpointName))
-				return true, ctrl.Result{}, err
+				return err
 			}
 			logger.Info(fmt.Sprintf("Deleted TestRun \"%s\" for endpoint \"%s\"", testRunName, endpointSpec.EndpointName))
+			r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonDeleted, fmt.Sprintf("Deleted TestRun \"%s\" for endpoint \"%s\"", testRunName, endpointSpec.EndpointName))
 		}
 
-		switch rc.EndpointDataOptions[endpointSpec.EndpointName] {
-		case windtunnelv1alpha1.EndpointDataOptionPlainText:
-			// ConfigMap
-			configMap := &corev1.ConfigMap{}
-			configMapName := types.NamespacedName{
-				Namespace: experiment.Namespace,
-				Name:      utils.GetTestRunName(experiment.Name, endpointIdx),
-			}
-			if err := r.Get(ctx, configMapName, configMap); client.IgnoreNotFound(err) != nil {
-				logger.Error(err, fmt.Sprintf("Lost ConfigMap \"%s\" for endpoint \"%s\"",
-					configMapName, endpointSpec.EndpointName,
-				))
-				return true, ctrl.Result{}, err
-			} else if err == nil {
-				if err := r.Delete(ctx, configMap); err != nil {
-					logger.Error(err, fmt.Sprintf("Cannot delete ConfigMap \"%s\" for endpoint \"%s\"",
-						configMapName, endpointSpec.EndpointName,
-					))
-					return true, ctrl.Result{}, err
-				}
-				logger.Info(fmt.Sprintf("Deleted ConfigMap \"%s\" for endpoint \"%s\"",
-					configMapName, endpointSpec.EndpointName,
-				))
-			}
-
-		case windtunnelv1alpha1.EndpointDataOptionDataSet:
-			// Copier Job
-			copierJob := &kbatch.Job{}
-			copierJobName := types.NamespacedName{
-				Namespace: experiment.Namespace,
-				Name:      utils.GetTestRunCopierJobName(experiment.Name, endpointIdx),
-			}
-			if err := r.Get(ctx, copierJobName, copierJob); client.IgnoreNotFound(err) != nil {
-				logger.Error(err, fmt.Sprintf("Lost copier Job \"%s\" for endpoint \"%s\"",
-					copierJobName, endpointSpec.EndpointName,
-				))
-				return true, ctrl.Result{}, err
-			} else if err == nil {
-				// By default, the Pod of the Job will be reserved after the Job is deleted,
-				// and Kubernetes will raise a warning.
-				// Set the propagation policy to "Background" to avoid the warning and delete the Pod.
-				if err := r.Delete(ctx, copierJob, &client.DeleteOptions{
-					PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
-				}); err != nil {
-					logger.Error(err, fmt.Sprintf("Cannot delete copier Job \"%s\" for endpoint \"%s\"",
-						copierJobName, endpointSpec.EndpointName,
-					))
-					return true, ctrl.Result{}, err
-				}
-				logger.Info(fmt.Sprintf("Deleted copier Job \"%s\" for endpoint \"%s\"",
+		// Copier Job, only created when the data is from a DataSet
+		copierJob := &kbatch.Job{}
+		copierJobName := types.NamespacedName{
+			Namespace: experiment.Namespace,
+			Name:      utils.GetTestRunCopierJobName(experiment.Name, endpointIdx),
+		}
+		if err := r.Get(ctx, copierJobName, copierJob); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, fmt.Sprintf("Lost copier Job \"%s\" for endpoint \"%s\"",
+				copierJobName, endpointSpec.EndpointName,
+			))
+			return err
+		} else if err == nil {
+			// By default, the Pod of the Job will be reserved after the Job is deleted,
+			// and Kubernetes will raise a warning.
+			// Set the propagation policy to "Background" to avoid the warning and delete the Pod.
+			if err := r.Delete(ctx, copierJob, &client.DeleteOptions{
+				PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+			}); err != nil {
+				logger.Error(err, fmt.Sprintf("Cannot delete copier Job \"%s\" for endpoint \"%s\"",
 					copierJobName, endpointSpec.EndpointName,
 				))
+				return err
 			}
+			logger.Info(fmt.Sprintf("Deleted copier Job \"%s\" for endpoint \"%s\"",
+				copierJobName, endpointSpec.EndpointName,
+			))
+			r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonDeleted, fmt.Sprintf("Deleted copier Job \"%s\" for endpoint \"%s\"",
+				copierJobName, endpointSpec.EndpointName,
+			))
+		}
 
-			// ConfigMap
-			configMap := &corev1.ConfigMap{}
-			configMapName := types.NamespacedName{
-				Namespace: experiment.Namespace,
-				Name:      utils.GetTestRunName(experiment.Name, endpointIdx),
-			}
-			if err := r.Get(ctx, configMapName, configMap); client.IgnoreNotFound(err) != nil {
-				logger.Error(err, fmt.Sprintf("Lost ConfigMap \"%s\" for endpoint \"%s\"",
-					configMapName, endpointSpec.EndpointName,
-				))
-				return true, ctrl.Result{}, err
-			} else if err == nil {
-				if err := r.Delete(ctx, configMap); err != nil {
-					logger.Error(err, fmt.Sprintf("Cannot delete ConfigMap \"%s\" for endpoint \"%s\"",
-						configMapName, endpointSpec.EndpointName,
-					))
-					return true, ctrl.Result{}, err
-				}
-				logger.Info(fmt.Sprintf("Deleted ConfigMap \"%s\" for endpoint \"%s\"",
+		// ConfigMap
+		configMap := &corev1.ConfigMap{}
+		configMapName := types.NamespacedName{
+			Namespace: experiment.Namespace,
+			Name:      utils.GetTestRunName(experiment.Name, endpointIdx),
+		}
+		if err := r.Get(ctx, configMapName, configMap); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, fmt.Sprintf("Lost ConfigMap \"%s\" for endpoint \"%s\"",
+				configMapName, endpointSpec.EndpointName,
+			))
+			return err
+		} else if err == nil {
+			if err := r.Delete(ctx, configMap); err != nil {
+				logger.Error(err, fmt.Sprintf("Cannot delete ConfigMap \"%s\" for endpoint \"%s\"",
 					configMapName, endpointSpec.EndpointName,
 				))
+				return err
 			}
+			logger.Info(fmt.Sprintf("Deleted ConfigMap \"%s\" for endpoint \"%s\"",
+				configMapName, endpointSpec.EndpointName,
+			))
+			r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonDeleted, fmt.Sprintf("Deleted ConfigMap \"%s\" for endpoint \"%s\"",
+				configMapName, endpointSpec.EndpointName,
+			))
+		}
 
-			// PVC
-			pvc := &corev1.PersistentVolumeClaim{}
-			pvcName := types.NamespacedName{
-				Namespace: experiment.Namespace,
-				Name:      utils.GetTestRunName(experiment.Name, endpointIdx),
-			}
-			if err := r.Get(ctx, pvcName, pvc); client.IgnoreNotFound(err) != nil {
-				logger.Error(err, fmt.Sprintf("Lost PVC \"%s\" for endpoint \"%s\"", pvcName, endpointSpec.EndpointName))
-				return true, ctrl.Result{}, err
-			} else if err == nil {
-				if err := r.Delete(ctx, pvc); err != nil {
-					logger.Error(err, fmt.Sprintf("Cannot delete PVC \"%s\" for endpoint \"%s\"", pvcName, endpointSpec.EndpointName))
-					return true, ctrl.Result{}, err
-				}
-				logger.Info(fmt.Sprintf("Deleted PVC \"%s\" for endpoint \"%s\"", pvcName, endpointSpec.EndpointName))
+		// PVC, only created when the data is from a DataSet
+		pvc := &corev1.PersistentVolumeClaim{}
+		pvcName := types.NamespacedName{
+			Namespace: experiment.Namespace,
+			Name:      utils.GetTestRunName(experiment.Name, endpointIdx),
+		}
+		if err := r.Get(ctx, pvcName, pvc); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, fmt.Sprintf("Lost PVC \"%s\" for endpoint \"%s\"", pvcName, endpointSpec.EndpointName))
+			return err
+		} else if err == nil {
+			if err := r.Delete(ctx, pvc); err != nil {
+				logger.Error(err, fmt.Sprintf("Cannot delete PVC \"%s\" for endpoint \"%s\"", pvcName, endpointSpec.EndpointName))
+				return err
 			}
+			logger.Info(fmt.Sprintf("Deleted PVC \"%s\" for endpoint \"%s\"", pvcName, endpointSpec.EndpointName))
+			r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonDeleted, fmt.Sprintf("Deleted PVC \"%s\" for endpoint \"%s\"", pvcName, endpointSpec.EndpointName))
 		}
 	}
 
-	// Proceed to the next state
-	experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentDraining
-	experiment.Status.DrainingStartTime = ptr.To(metav1.Now())
-	return false, ctrl.Result{}, nil
+	return nil
 }
 
-// reconcileDraining reconciles the Experiment when it is draining.
+// reconcileCancelling stops the Experiment when it is requested to be cancelled, regardless of its current state.
+// It stops the load generators, skips draining, and releases the Pipeline, while keeping the start time.
 // It returns a flag of whether the current reconciliation loop should stop,
 // the reconciliation result, and an error, if any.
-func (r *ExperimentReconciler) reconcileDraining(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, rc *ExperimentReconcilerContext) (bool, ctrl.Result, error) {
+func (r *ExperimentReconciler) reconcileCancelling(ctx context.Context, experiment *windtunnelv1alpha1.Experiment) (bool, ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	curTime := time.Now()
+	// Stop the load generators and remove the resources created for them
+	if err := r.deleteLoadGeneratorResources(ctx, experiment); err != nil {
+		return true, ctrl.Result{}, err
+	}
 
-	// Check if the end detector Job is finished
+	// Stop the end detector Job
 	if experiment.Spec.UseEndDetection {
 		endDetectorJob := &kbatch.Job{}
 		endDetectorJobName := types.NamespacedName{
 			Namespace: experiment.Namespace,
 			Name:      utils.GetEndDetectorJobName(experiment.Name),
 		}
-		if err := r.Get(ctx, endDetectorJobName, endDetectorJob); err != nil {
+		if err := r.Get(ctx, endDetectorJobName, endDetectorJob); client.IgnoreNotFound(err) != nil {
 			logger.Error(err, fmt.Sprintf("Lost end detector Job \"%s\"", endDetectorJobName))
-			experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentFailed
-			experiment.Status.Error = fmt.Sprintf("Lost end detector Job \"%s\": %s", endDetectorJobName, err)
-		}
-		jobFinished, jobConditionType := isJobFinished(endDetectorJob)
-		if jobFinished {
-			logger.Info(fmt.Sprintf("End detector Job \"%s\" finished", endDetectorJobName))
-			switch jobConditionType {
-			case kbatch.JobComplete:
-				experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentCompleted
-				calculatedCompletionTime := curTime.Add(-experimentEndDetectorAdjustment * time.Second)
-				experiment.Status.CompletionTime = &metav1.Time{Time: calculatedCompletionTime}
-			case kbatch.JobFailed:
-				logger.Error(nil, fmt.Sprintf("End detector Job \"%s\" failed", endDetectorJobName))
-				experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentFailed
-				experiment.Status.Error = fmt.Sprintf("End detector Job \"%s\" failed", endDetectorJobName)
+			return true, ctrl.Result{}, err
+		} else if err == nil {
+			if err := r.Delete(ctx, endDetectorJob, &client.DeleteOptions{
+				PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+			}); err != nil {
+				logger.Error(err, fmt.Sprintf("Cannot delete end detector Job \"%s\"", endDetectorJobName))
+				return true, ctrl.Result{}, err
 			}
+			logger.Info(fmt.Sprintf("Deleted end detector Job \"%s\"", endDetectorJobName))
+			r.Recorder.Event(experiment, corev1.EventTypeNormal, eventReasonDeleted, fmt.Sprintf("Deleted end detector Job \"%s\"", endDetectorJobName))
+		}
+	}
+
+	// Release the Pipeline if the Experiment is holding it or waiting in its queue
+	switch experiment.Status.JobStatus {
+	case windtunnelv1alpha1.ExperimentWaitingPipeline, windtunnelv1alpha1.ExperimentInitializing,
+		windtunnelv1alpha1.ExperimentRunning, windtunnelv1alpha1.ExperimentDraining:
+		pipeline := &windtunnelv1alpha1.Pipeline{}
+		pipelineName := types.NamespacedName{
+			Namespace: experiment.Namespace,
+			Name:      experiment.Spec.PipelineRef.Name,
+		}
+		if err := r.Get(ctx, pipelineName, pipeline); err != nil {
+			logger.Error(err, fmt.Sprintf("Lost Pipeline \"%s\"", pipelineName))
 		} else {
-			return true, ctrl.Result{RequeueAfter: experimentPollingInterval}, nil
+			if err := r.releasePipeline(ctx, experiment, pipeline); err != nil {
+				return true, ctrl.Result{}, err
+			}
 		}
 	}
 
-	// Check if the draining time has been fulfilled
-	if !experiment.Spec.UseEndDetection && experiment.Spec.DrainingTime != nil && curTime.Before(experiment.Status.DrainingStartTime.Time.Add(experiment.Spec.DrainingTime.Duration)) {
-		// Time has not been fulfilled, calculate the waiting time
-		waitTime := experiment.Status.DrainingStartTime.Time.Add(experiment.Spec.DrainingTime.Duration).Sub(curTime)
-		logger.Info(fmt.Sprintf("Pipeline is draining, waiting for \"%s\"", waitTime))
-		return true, ctrl.Result{RequeueAfter: waitTime}, nil
+	// Stop the reconciliation loop
+	logger.Info("Cancelled the Experiment")
+	experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentCancelled
+	experiment.Status.QueuePosition = 0
+	if experiment.Status.CompletionTime == nil {
+		experiment.Status.CompletionTime = ptr.To(metav1.Now())
 	}
+	return true, ctrl.Result{}, nil
+}
 
-	// Release the Pipeline
-	if err := r.releasePipeline(ctx, rc.Pipeline); err != nil {
-		return true, ctrl.Result{}, err
+// getPipelineQueue gets the names of the Experiments waiting for the Pipeline, in the order they will acquire it,
+// including the given Experiment.
+func (r *ExperimentReconciler) getPipelineQueue(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, pipeline *windtunnelv1alpha1.Pipeline) ([]string, error) {
+	experimentList := &windtunnelv1alpha1.ExperimentList{}
+	if err := r.List(ctx, experimentList,
+		client.InNamespace(pipeline.Namespace),
+		client.MatchingFields{experimentPipelineIndexKey: pipeline.Name},
+	); err != nil {
+		return nil, err
 	}
 
-	// Stop the reconciliation loop
-	experiment.Status.JobStatus = windtunnelv1alpha1.ExperimentCompleted
-	experiment.Status.CompletionTime = ptr.To(metav1.Now())
-	return true, ctrl.Result{}, nil
+	// The given Experiment may have just started waiting, and its status in the cache may be outdated
+	waitingExperiments := []*windtunnelv1alpha1.Experiment{experiment}
+	for i := range experimentList.Items {
+		waitingExperiment := &experimentList.Items[i]
+		if waitingExperiment.Name != experiment.Name && waitingExperiment.DeletionTimestamp.IsZero() &&
+			waitingExperiment.Status.JobStatus == windtunnelv1alpha1.ExperimentWaitingPipeline {
+			waitingExperiments = append(waitingExperiments, waitingExperiment)
+		}
+	}
+	slices.SortFunc(waitingExperiments, compareQueuedExperiments)
+
+	queue := make([]string, len(waitingExperiments))
+	for i, waitingExperiment := range waitingExperiments {
+		queue[i] = waitingExperiment.Name
+	}
+	return queue, nil
+}
+
+// compareQueuedExperiments compares two Experiments by the order they acquire the Pipeline.
+// Experiments with higher priority go first, followed by those with earlier scheduled time, or creation time
+// if not scheduled. Ties are broken by the name, so that every reconciliation agrees on the order.
+func compareQueuedExperiments(a, b *windtunnelv1alpha1.Experiment) int {
+	if a.Spec.Priority != b.Spec.Priority {
+		return cmp.Compare(b.Spec.Priority, a.Spec.Priority)
+	}
+	if c := getExperimentQueueTime(a).Compare(getExperimentQueueTime(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+// getExperimentQueueTime gets the time used to order the Experiment in the queue of the Pipeline.
+func getExperimentQueueTime(experiment *windtunnelv1alpha1.Experiment) time.Time {
+	if experiment.Spec.ScheduledTime != nil {
+		return experiment.Spec.ScheduledTime.Time
+	}
+	return experiment.CreationTimestamp.Time
 }
 
-// releasePipeline unlocks the Pipeline by setting its status to "Ready".
+// holdsPipeline returns whether the Experiment holds the Pipeline. A Pipeline without the holder recorded is held by
+// the Experiment only if the Experiment has started to use it, so that it is never unlocked by an Experiment that
+// is waiting in the queue or has already released it.
+func holdsPipeline(experiment *windtunnelv1alpha1.Experiment, pipeline *windtunnelv1alpha1.Pipeline) bool {
+	if pipeline.Status.Holder != "" {
+		return pipeline.Status.Holder == experiment.Name
+	}
+	switch experiment.Status.JobStatus {
+	case windtunnelv1alpha1.ExperimentInitializing, windtunnelv1alpha1.ExperimentRunning, windtunnelv1alpha1.ExperimentDraining:
+		return true
+	default:
+		return false
+	}
+}
+
+// releasePipeline unlocks the Pipeline held by the Experiment by setting its status to "Ready".
 // It also removes the Experiment label from the metrics Service.
-func (r *ExperimentReconciler) releasePipeline(ctx context.Context, pipeline *windtunnelv1alpha1.Pipeline) error {
+// If the Pipeline is not held by the Experiment, it only removes the Experiment from the queue of the Pipeline.
+func (r *ExperimentReconciler) releasePipeline(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, pipeline *windtunnelv1alpha1.Pipeline) error {
 	logger := log.FromContext(ctx)
 
+	if !holdsPipeline(experiment, pipeline) {
+		if idx := slices.Index(pipeline.Status.Queue, experiment.Name); idx != -1 {
+			pipeline.Status.Queue = slices.Delete(pipeline.Status.Queue, idx, idx+1)
+			if err := r.Status().Update(ctx, pipeline); err != nil {
+				logger.Error(err, "Cannot update the queue of the Pipeline")
+				return err
+			}
+		}
+		return nil
+	}
+
 	// Unlock the Pipeline by setting its status to "Ready"
 	pipeline.Status.Availability = windtunnelv1alpha1.PipelineReady
+	pipeline.Status.Holder = ""
+	if idx := slices.Index(pipeline.Status.Queue, experiment.Name); idx != -1 {
+		pipeline.Status.Queue = slices.Delete(pipeline.Status.Queue, idx, idx+1)
+	}
 	if err := r.Status().Update(ctx, pipeline); err != nil {
 		logger.Error(err, "Cannot update the status of the Pipeline")
 		return err
@@ -906,9 +1347,81 @@ func (r *ExperimentReconciler) releasePipeline(ctx context.Context, pipeline *wi
 	return nil
 }
 
+// findExperimentsForPipeline maps a Pipeline to the Experiments using it, so that Experiments waiting for the
+// Pipeline are reconciled as soon as it becomes available.
+func (r *ExperimentReconciler) findExperimentsForPipeline(ctx context.Context, pipeline client.Object) []reconcile.Request {
+	return r.findExperiments(ctx, pipeline, experimentPipelineIndexKey)
+}
+
+// findExperimentsForDataSet maps a DataSet to the Experiments using it, so that Experiments waiting for the
+// DataSet are reconciled as soon as its generation finishes.
+func (r *ExperimentReconciler) findExperimentsForDataSet(ctx context.Context, dataSet client.Object) []reconcile.Request {
+	return r.findExperiments(ctx, dataSet, experimentDataSetIndexKey)
+}
+
+// findExperiments lists the Experiments in the namespace of the object, whose index contains the name of the object.
+func (r *ExperimentReconciler) findExperiments(ctx context.Context, obj client.Object, indexKey string) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	experimentList := &windtunnelv1alpha1.ExperimentList{}
+	if err := r.List(ctx, experimentList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{indexKey: obj.GetName()},
+	); err != nil {
+		logger.Error(err, fmt.Sprintf("Cannot list Experiments by index \"%s\"", indexKey))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(experimentList.Items))
+	for i, experiment := range experimentList.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: experiment.Namespace,
+				Name:      experiment.Name,
+			},
+		}
+	}
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ExperimentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &windtunnelv1alpha1.Experiment{}, experimentPipelineIndexKey, func(obj client.Object) []string {
+		experiment := obj.(*windtunnelv1alpha1.Experiment)
+		if experiment.Spec.PipelineRef == nil || experiment.Spec.PipelineRef.Name == "" {
+			return nil
+		}
+		return []string{experiment.Spec.PipelineRef.Name}
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &windtunnelv1alpha1.Experiment{}, experimentDataSetIndexKey, func(obj client.Object) []string {
+		experiment := obj.(*windtunnelv1alpha1.Experiment)
+		var dataSetNames []string
+		for _, endpointSpec := range experiment.Spec.EndpointSpecs {
+			if getEndpointSpecDataOption(&endpointSpec) == windtunnelv1alpha1.EndpointDataOptionDataSet {
+				dataSetNames = append(dataSetNames, endpointSpec.DataSpec.DataSetRef.Name)
+			}
+		}
+		return dataSetNames
+	}); err != nil {
+		return err
+	}
+
+	// Count the Experiments in each status from the cache when the metrics are scraped
+	if err := metrics.Registry.Register(newExperimentCollector(mgr.GetClient())); err != nil {
+		return err
+	}
+
+	// Changes of the created resources and the referenced Pipeline and DataSets trigger the state transitions,
+	// polling only serves as a fallback
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&windtunnelv1alpha1.Experiment{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&kbatch.Job{}).
+		Owns(&k6v1alpha1.TestRun{}).
+		Watches(&windtunnelv1alpha1.Pipeline{}, handler.EnqueueRequestsFromMapFunc(r.findExperimentsForPipeline)).
+		Watches(&windtunnelv1alpha1.DataSet{}, handler.EnqueueRequestsFromMapFunc(r.findExperimentsForDataSet)).
 		Complete(r)
 }
@@ -0,0 +1,352 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/utils"
+)
+
+const (
+	// experimentScheduleOwnerIndexKey indexes Experiments by the name of the ExperimentSchedule controlling them.
+	experimentScheduleOwnerIndexKey = ".metadata.controller"
+	// experimentScheduleScheduledTimeAnnotation is the annotation of the Experiments recording the time
+	// they are scheduled by the ExperimentSchedule.
+	experimentScheduleScheduledTimeAnnotation = "windtunnel.plantd.org/scheduled-at"
+	// experimentScheduleMaxMissedSchedules is the maximum number of missed schedules to iterate over. Beyond it,
+	// only the latest missed schedule is searched for.
+	experimentScheduleMaxMissedSchedules = 100
+
+	experimentScheduleDefaultSuccessfulHistoryLimit = 3
+	experimentScheduleDefaultFailedHistoryLimit     = 1
+)
+
+// ExperimentScheduleReconciler reconciles a ExperimentSchedule object
+type ExperimentScheduleReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=experimentschedules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=experimentschedules/status,verbs=get;update;patch
+//
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=experiments,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.5/pkg/reconcile
+func (r *ExperimentScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the requested ExperimentSchedule
+	experimentSchedule := &windtunnelv1alpha1.ExperimentSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, experimentSchedule); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to fetch ExperimentSchedule")
+		return ctrl.Result{}, err
+	}
+
+	// List the Experiments created by the ExperimentSchedule
+	experimentList := &windtunnelv1alpha1.ExperimentList{}
+	if err := r.List(ctx, experimentList,
+		client.InNamespace(experimentSchedule.Namespace),
+		client.MatchingFields{experimentScheduleOwnerIndexKey: experimentSchedule.Name},
+	); err != nil {
+		logger.Error(err, "Cannot list Experiments")
+		return ctrl.Result{}, err
+	}
+
+	// Sort the Experiments by their scheduled time, and categorize them by their status
+	var activeExperiments, completedExperiments, failedExperiments []*windtunnelv1alpha1.Experiment
+	var lastScheduleTime *time.Time
+	for i := range experimentList.Items {
+		experiment := &experimentList.Items[i]
+		switch experiment.Status.JobStatus {
+		case windtunnelv1alpha1.ExperimentCompleted:
+			completedExperiments = append(completedExperiments, experiment)
+		case windtunnelv1alpha1.ExperimentFailed, windtunnelv1alpha1.ExperimentCancelled:
+			failedExperiments = append(failedExperiments, experiment)
+		default:
+			activeExperiments = append(activeExperiments, experiment)
+		}
+
+		scheduledTime, err := getExperimentScheduledTime(experiment)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Cannot parse the scheduled time of Experiment \"%s\"", experiment.Name))
+			continue
+		}
+		if scheduledTime != nil && (lastScheduleTime == nil || scheduledTime.After(*lastScheduleTime)) {
+			lastScheduleTime = scheduledTime
+		}
+	}
+
+	experimentSchedule.Status.Active = make([]corev1.ObjectReference, 0, len(activeExperiments))
+	for _, experiment := range activeExperiments {
+		experimentSchedule.Status.Active = append(experimentSchedule.Status.Active, corev1.ObjectReference{
+			APIVersion: windtunnelv1alpha1.GroupVersion.String(),
+			Kind:       "Experiment",
+			Namespace:  experiment.Namespace,
+			Name:       experiment.Name,
+			UID:        experiment.UID,
+		})
+	}
+	// Keep the recorded time if the Experiments have been deleted, so that their schedules are not run again
+	if lastScheduleTime != nil && (experimentSchedule.Status.LastScheduleTime == nil || lastScheduleTime.After(experimentSchedule.Status.LastScheduleTime.Time)) {
+		experimentSchedule.Status.LastScheduleTime = &metav1.Time{Time: *lastScheduleTime}
+	}
+
+	// Remove the finished Experiments exceeding the history limits
+	successfulHistoryLimit := int32(experimentScheduleDefaultSuccessfulHistoryLimit)
+	if experimentSchedule.Spec.SuccessfulExperimentsHistoryLimit != nil {
+		successfulHistoryLimit = *experimentSchedule.Spec.SuccessfulExperimentsHistoryLimit
+	}
+	failedHistoryLimit := int32(experimentScheduleDefaultFailedHistoryLimit)
+	if experimentSchedule.Spec.FailedExperimentsHistoryLimit != nil {
+		failedHistoryLimit = *experimentSchedule.Spec.FailedExperimentsHistoryLimit
+	}
+	if err := r.deleteOldExperiments(ctx, experimentSchedule, completedExperiments, successfulHistoryLimit); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.deleteOldExperiments(ctx, experimentSchedule, failedExperiments, failedHistoryLimit); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Find the missed schedule to run and the next schedule
+	now := time.Now()
+	missedRun, nextRun, tooManyMissed, err := getExperimentScheduleRuns(experimentSchedule, now)
+	if err != nil {
+		logger.Error(err, "Cannot get the schedule")
+		setDegradedConditions(&experimentSchedule.Status.Conditions, experimentSchedule.Generation, windtunnelv1alpha1.ReasonInvalidSpec, err.Error())
+		experimentSchedule.Status.NextScheduleTime = nil
+		if err := r.Status().Update(ctx, experimentSchedule); err != nil {
+			logger.Error(err, "Cannot update the status")
+			return ctrl.Result{}, err
+		}
+		// The schedule cannot be fixed without changing the spec, so there is no need to requeue
+		return ctrl.Result{}, nil
+	}
+	if experimentSchedule.Spec.Suspend {
+		setReadyConditions(&experimentSchedule.Status.Conditions, experimentSchedule.Generation, windtunnelv1alpha1.ReasonSuspended, "")
+		experimentSchedule.Status.NextScheduleTime = nil
+	} else {
+		setReadyConditions(&experimentSchedule.Status.Conditions, experimentSchedule.Generation, windtunnelv1alpha1.ReasonReconciled, "")
+		experimentSchedule.Status.NextScheduleTime = &metav1.Time{Time: nextRun}
+	}
+	if err := r.Status().Update(ctx, experimentSchedule); err != nil {
+		logger.Error(err, "Cannot update the status")
+		return ctrl.Result{}, err
+	}
+
+	// No need to create Experiments if the ExperimentSchedule is suspended
+	if experimentSchedule.Spec.Suspend {
+		return ctrl.Result{}, nil
+	}
+
+	// Requeue at the next schedule, or when a newer Experiment is created
+	result := ctrl.Result{RequeueAfter: nextRun.Sub(now)}
+	if missedRun.IsZero() {
+		return result, nil
+	}
+
+	// Only the latest missed schedule is run anyway, but warn that the earlier ones are dropped
+	if tooManyMissed {
+		logger.Info(fmt.Sprintf("Missed more than %d schedules, running the latest one at \"%s\"", experimentScheduleMaxMissedSchedules, missedRun))
+		r.Recorder.Event(experimentSchedule, corev1.EventTypeWarning, "TooManyMissedSchedules",
+			fmt.Sprintf("Missed more than %d schedules, set or decrease startingDeadlineSeconds", experimentScheduleMaxMissedSchedules),
+		)
+	}
+
+	// Skip the missed schedule if it is too late to start
+	if experimentSchedule.Spec.StartingDeadlineSeconds != nil &&
+		missedRun.Add(time.Duration(*experimentSchedule.Spec.StartingDeadlineSeconds)*time.Second).Before(now) {
+		logger.Info(fmt.Sprintf("Missed the starting deadline of the schedule at \"%s\"", missedRun))
+		r.Recorder.Event(experimentSchedule, corev1.EventTypeWarning, "MissedSchedule",
+			fmt.Sprintf("Missed the starting deadline of the schedule at \"%s\"", missedRun),
+		)
+		return result, nil
+	}
+
+	// Apply the concurrency policy
+	switch experimentSchedule.Spec.ConcurrencyPolicy {
+	case windtunnelv1alpha1.ExperimentScheduleConcurrencyForbid:
+		if len(activeExperiments) > 0 {
+			logger.Info(fmt.Sprintf("Skipped the schedule at \"%s\" because %d Experiment(s) are still running", missedRun, len(activeExperiments)))
+			return result, nil
+		}
+	case windtunnelv1alpha1.ExperimentScheduleConcurrencyReplace:
+		for _, experiment := range activeExperiments {
+			if err := r.Delete(ctx, experiment, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				logger.Error(err, fmt.Sprintf("Cannot delete Experiment \"%s\"", experiment.Name))
+				return ctrl.Result{}, err
+			}
+			logger.Info(fmt.Sprintf("Deleted Experiment \"%s\"", experiment.Name))
+			r.Recorder.Event(experimentSchedule, corev1.EventTypeNormal, eventReasonDeleted, fmt.Sprintf("Deleted Experiment \"%s\"", experiment.Name))
+		}
+	}
+
+	// Create the Experiment for the missed schedule
+	experiment := newScheduledExperiment(experimentSchedule, missedRun)
+	if err := ctrl.SetControllerReference(experimentSchedule, experiment, r.Scheme); err != nil {
+		logger.Error(err, "Cannot set controller reference for Experiment")
+		return ctrl.Result{}, err
+	}
+	if err := r.Create(ctx, experiment); client.IgnoreAlreadyExists(err) != nil {
+		logger.Error(err, fmt.Sprintf("Cannot create Experiment \"%s\"", experiment.Name))
+		return ctrl.Result{}, err
+	} else if err == nil {
+		logger.Info(fmt.Sprintf("Created Experiment \"%s\"", experiment.Name))
+		r.Recorder.Event(experimentSchedule, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created Experiment \"%s\"", experiment.Name))
+	}
+
+	return result, nil
+}
+
+// deleteOldExperiments deletes the oldest finished Experiments, so that at most limit Experiments are kept.
+func (r *ExperimentScheduleReconciler) deleteOldExperiments(ctx context.Context, experimentSchedule *windtunnelv1alpha1.ExperimentSchedule, experiments []*windtunnelv1alpha1.Experiment, limit int32) error {
+	logger := log.FromContext(ctx)
+
+	if len(experiments) <= int(limit) {
+		return nil
+	}
+
+	slices.SortFunc(experiments, func(a, b *windtunnelv1alpha1.Experiment) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+	for _, experiment := range experiments[:len(experiments)-int(limit)] {
+		if err := r.Delete(ctx, experiment, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, fmt.Sprintf("Cannot delete old Experiment \"%s\"", experiment.Name))
+			return err
+		}
+		logger.Info(fmt.Sprintf("Deleted old Experiment \"%s\"", experiment.Name))
+		r.Recorder.Event(experimentSchedule, corev1.EventTypeNormal, eventReasonDeleted, fmt.Sprintf("Deleted old Experiment \"%s\"", experiment.Name))
+	}
+	return nil
+}
+
+// getExperimentScheduledTime gets the time the Experiment is scheduled by the ExperimentSchedule,
+// or nil if the Experiment is not created by an ExperimentSchedule.
+func getExperimentScheduledTime(experiment *windtunnelv1alpha1.Experiment) (*time.Time, error) {
+	value, ok := experiment.Annotations[experimentScheduleScheduledTimeAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	scheduledTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &scheduledTime, nil
+}
+
+// getExperimentScheduleRuns gets the latest schedule missed since the last run, or zero time if none is missed,
+// the next schedule after now, and whether more than experimentScheduleMaxMissedSchedules schedules are missed.
+func getExperimentScheduleRuns(experimentSchedule *windtunnelv1alpha1.ExperimentSchedule, now time.Time) (time.Time, time.Time, bool, error) {
+	schedule, err := cron.ParseStandard(experimentSchedule.Spec.Schedule)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("invalid schedule \"%s\": %w", experimentSchedule.Spec.Schedule, err)
+	}
+
+	// Start from the last run, or the creation of the ExperimentSchedule if it never ran
+	earliestTime := experimentSchedule.CreationTimestamp.Time
+	if experimentSchedule.Status.LastScheduleTime != nil {
+		earliestTime = experimentSchedule.Status.LastScheduleTime.Time
+	}
+	// Schedules earlier than the starting deadline will be skipped anyway
+	if experimentSchedule.Spec.StartingDeadlineSeconds != nil {
+		deadlineTime := now.Add(-time.Duration(*experimentSchedule.Spec.StartingDeadlineSeconds) * time.Second)
+		if deadlineTime.After(earliestTime) {
+			earliestTime = deadlineTime
+		}
+	}
+	if earliestTime.After(now) {
+		return time.Time{}, schedule.Next(now), false, nil
+	}
+
+	var missedRun time.Time
+	numMissed := 0
+	for t := schedule.Next(earliestTime); !t.After(now); t = schedule.Next(t) {
+		missedRun = t
+		numMissed++
+		if numMissed > experimentScheduleMaxMissedSchedules {
+			return getLatestSchedule(schedule, earliestTime, now), schedule.Next(now), true, nil
+		}
+	}
+	return missedRun, schedule.Next(now), false, nil
+}
+
+// getLatestSchedule gets the latest schedule after earliestTime and not after now, or zero time if there is none.
+// It searches the windows before now of doubling lengths, so that only the schedules in the shortest window
+// containing any are iterated over instead of all the schedules since earliestTime.
+func getLatestSchedule(schedule cron.Schedule, earliestTime, now time.Time) time.Time {
+	for window := time.Minute; ; window *= 2 {
+		start := now.Add(-window)
+		if start.Before(earliestTime) {
+			start = earliestTime
+		}
+		var latest time.Time
+		for t := schedule.Next(start); !t.After(now); t = schedule.Next(t) {
+			latest = t
+		}
+		if !latest.IsZero() || !start.After(earliestTime) {
+			return latest
+		}
+	}
+}
+
+// newScheduledExperiment creates the Experiment from the template of the ExperimentSchedule for the scheduled time.
+func newScheduledExperiment(experimentSchedule *windtunnelv1alpha1.ExperimentSchedule, scheduledTime time.Time) *windtunnelv1alpha1.Experiment {
+	template := experimentSchedule.Spec.ExperimentTemplate
+	experiment := &windtunnelv1alpha1.Experiment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   experimentSchedule.Namespace,
+			Name:        utils.GetScheduledExperimentName(experimentSchedule.Name, scheduledTime),
+			Labels:      make(map[string]string, len(template.Labels)),
+			Annotations: make(map[string]string, len(template.Annotations)+1),
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	for key, value := range template.Labels {
+		experiment.Labels[key] = value
+	}
+	for key, value := range template.Annotations {
+		experiment.Annotations[key] = value
+	}
+	experiment.Annotations[experimentScheduleScheduledTimeAnnotation] = scheduledTime.Format(time.RFC3339)
+	experiment.Spec.ScheduledTime = &metav1.Time{Time: scheduledTime}
+	return experiment
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ExperimentScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &windtunnelv1alpha1.Experiment{}, experimentScheduleOwnerIndexKey, func(obj client.Object) []string {
+		owner := metav1.GetControllerOf(obj)
+		if owner == nil || owner.APIVersion != windtunnelv1alpha1.GroupVersion.String() || owner.Kind != "ExperimentSchedule" {
+			return nil
+		}
+		return []string{owner.Name}
+	}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&windtunnelv1alpha1.ExperimentSchedule{}).
+		Owns(&windtunnelv1alpha1.Experiment{}).
+		Complete(r)
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+)
+
+// LoadPatternReconciler reconciles a LoadPattern object
+type LoadPatternReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=loadpatterns,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=loadpatterns/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.5/pkg/reconcile
+func (r *LoadPatternReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the requested LoadPattern
+	loadPattern := &windtunnelv1alpha1.LoadPattern{}
+	if err := r.Get(ctx, req.NamespacedName, loadPattern); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to fetch LoadPattern")
+		return ctrl.Result{}, err
+	}
+
+	// The Experiments using the LoadPattern need the durations of its stages
+	checkErr := checkLoadPattern(loadPattern)
+	if setValidatedConditions(&loadPattern.Status.Conditions, loadPattern.Generation, windtunnelv1alpha1.ReasonInvalidSpec, checkErr) {
+		if err := r.Status().Update(ctx, loadPattern); err != nil {
+			logger.Error(err, "Cannot update the status")
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// checkLoadPattern checks that the durations of the stages in the LoadPattern can be parsed.
+func checkLoadPattern(loadPattern *windtunnelv1alpha1.LoadPattern) error {
+	_, err := getLoadPatternDuration(loadPattern)
+	return err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LoadPatternReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&windtunnelv1alpha1.LoadPattern{}).
+		Complete(r)
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	kbatch "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+)
+
+const (
+	// metricsNamespace is the prefix of the names of the metrics exposed by the operator.
+	metricsNamespace = "plantd"
+	// experimentCollectorTimeout is the timeout of listing the Experiments when the metrics are scraped.
+	experimentCollectorTimeout = 10 * time.Second
+	// metricsResultSuccess and metricsResultFailure are the values of the "result" label.
+	metricsResultSuccess = "success"
+	metricsResultFailure = "failure"
+)
+
+var (
+	// experimentDurationSeconds observes the time between the start of the load generation and the start of draining.
+	experimentDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "experiment_duration_seconds",
+		Help:      "Duration of the load generation of Experiments.",
+		Buckets:   prometheus.ExponentialBuckets(30, 2, 10),
+	}, []string{"namespace"})
+	// experimentDurationRatio observes the actual duration of the load generation divided by the duration calculated
+	// from the LoadPatterns, so that Experiments running much longer than expected can be alerted on.
+	experimentDurationRatio = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "experiment_duration_ratio",
+		Help:      "Duration of the load generation of Experiments divided by the duration calculated from their LoadPatterns.",
+		Buckets:   []float64{0.9, 1, 1.05, 1.1, 1.25, 1.5, 2, 3, 5},
+	}, []string{"namespace"})
+	// experimentWaitingPipelineSeconds observes the time Experiments spent waiting for their Pipelines.
+	experimentWaitingPipelineSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "experiment_waiting_pipeline_seconds",
+		Help:      "Time Experiments spent in the \"Waiting for Pipeline\" status.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	}, []string{"namespace"})
+	// dataSetGenerationSeconds observes the run time of the data generator Jobs.
+	dataSetGenerationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "dataset_generation_seconds",
+		Help:      "Run time of the data generator Jobs of DataSets.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	}, []string{"namespace", "result"})
+	// dataSetGeneratedBytes observes the size of the files generated for DataSets.
+	dataSetGeneratedBytes = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "dataset_generated_bytes",
+		Help:      "Size of the files generated by the data generator Jobs of DataSets.",
+		Buckets:   prometheus.ExponentialBuckets(1<<20, 4, 10),
+	}, []string{"namespace"})
+	// simulationDurationSeconds observes the run time of the Simulation Jobs.
+	simulationDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "simulation_duration_seconds",
+		Help:      "Run time of the Jobs of Simulations.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	}, []string{"namespace", "result"})
+	// costExporterRunsTotal counts the finished cost exporter Jobs.
+	costExporterRunsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "cost_exporter_runs_total",
+		Help:      "Number of finished cost exporter Jobs.",
+	}, []string{"namespace", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		experimentDurationSeconds,
+		experimentDurationRatio,
+		experimentWaitingPipelineSeconds,
+		dataSetGenerationSeconds,
+		dataSetGeneratedBytes,
+		simulationDurationSeconds,
+		costExporterRunsTotal,
+	)
+}
+
+// experimentCollector collects the number of Experiments in each status when the metrics are scraped.
+// Counting at scrape time keeps the numbers correct when Experiments are deleted.
+type experimentCollector struct {
+	reader client.Reader
+	desc   *prometheus.Desc
+}
+
+// newExperimentCollector creates a new experimentCollector reading the Experiments with the given reader.
+func newExperimentCollector(reader client.Reader) *experimentCollector {
+	return &experimentCollector{
+		reader: reader,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "experiments"),
+			"Number of Experiments in each status.",
+			[]string{"namespace", "status"}, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *experimentCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *experimentCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), experimentCollectorTimeout)
+	defer cancel()
+
+	experimentList := &windtunnelv1alpha1.ExperimentList{}
+	if err := c.reader.List(ctx, experimentList); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+
+	type key struct {
+		namespace string
+		status    windtunnelv1alpha1.ExperimentJobStatus
+	}
+	counts := make(map[key]int)
+	for _, experiment := range experimentList.Items {
+		status := experiment.Status.JobStatus
+		if status == "" {
+			status = "Created"
+		}
+		counts[key{namespace: experiment.Namespace, status: status}]++
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), k.namespace, string(k.status))
+	}
+}
+
+// observeJobDuration observes the run time of a Job in the histogram, if the Job has started.
+// The completion time of a failed Job is not set, so the current time is used instead.
+func observeJobDuration(histogram *prometheus.HistogramVec, job *kbatch.Job, labels ...string) {
+	if job.Status.StartTime == nil {
+		return
+	}
+	endTime := time.Now()
+	if job.Status.CompletionTime != nil {
+		endTime = job.Status.CompletionTime.Time
+	}
+	histogram.WithLabelValues(labels...).Observe(endTime.Sub(job.Status.StartTime.Time).Seconds())
+}
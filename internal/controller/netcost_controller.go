@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+)
+
+// NetCostReconciler reconciles a NetCost object
+type NetCostReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=netcosts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=netcosts/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.5/pkg/reconcile
+func (r *NetCostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the requested NetCost
+	netCost := &windtunnelv1alpha1.NetCost{}
+	if err := r.Get(ctx, req.NamespacedName, netCost); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to fetch NetCost")
+		return ctrl.Result{}, err
+	}
+
+	// The costs are given as strings, and must be parsed by the simulations
+	checkErr := checkNetCost(netCost)
+	if setValidatedConditions(&netCost.Status.Conditions, netCost.Generation, windtunnelv1alpha1.ReasonInvalidSpec, checkErr) {
+		if err := r.Status().Update(ctx, netCost); err != nil {
+			logger.Error(err, "Cannot update the status")
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// checkNetCost checks that the costs in the NetCost are non-negative numbers.
+func checkNetCost(netCost *windtunnelv1alpha1.NetCost) error {
+	costs := []struct {
+		name  string
+		value string
+	}{
+		{"netCostPerMB", netCost.Spec.NetCostPerMB},
+		{"rawDataStoreCostPerMBMonth", netCost.Spec.RawDataStoreCostPerMBMonth},
+		{"processedDataStoreCostPerMBMonth", netCost.Spec.ProcessedDataStoreCostPerMBMonth},
+	}
+	for _, cost := range costs {
+		value, err := strconv.ParseFloat(cost.value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s \"%s\": %w", cost.name, cost.value, err)
+		}
+		if value < 0 {
+			return fmt.Errorf("invalid %s \"%s\": must not be negative", cost.name, cost.value)
+		}
+	}
+	if netCost.Spec.RawDataRetentionPolicyMonths < 0 {
+		return fmt.Errorf("invalid rawDataRetentionPolicyMonths %d: must not be negative", netCost.Spec.RawDataRetentionPolicyMonths)
+	}
+	if netCost.Spec.ProcessedDataRetentionPolicyMonths < 0 {
+		return fmt.Errorf("invalid processedDataRetentionPolicyMonths %d: must not be negative", netCost.Spec.ProcessedDataRetentionPolicyMonths)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NetCostReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&windtunnelv1alpha1.NetCost{}).
+		Complete(r)
+}
@@ -8,6 +8,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -29,7 +30,8 @@ var (
 // PipelineReconciler reconciles a Pipeline object
 type PipelineReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=pipelines,verbs=get;list;watch;create;update;patch;delete
@@ -84,16 +86,40 @@ func (r *PipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	// Initialize the Pipeline
+	var initErr error
 	if pipeline.Status.Availability == "" {
-		if err := r.initializeMonitor(ctx, pipeline); err != nil {
+		initErr = r.initializeMonitor(ctx, pipeline)
+	}
+
+	// Keep the conditions in line with the availability, which is also changed by the Experiments using the Pipeline
+	if setPipelineConditions(pipeline, initErr) {
+		if err := r.Status().Update(ctx, pipeline); err != nil {
+			logger.Error(err, "Cannot update the status")
 			return ctrl.Result{}, err
 		}
 	}
+	if initErr != nil {
+		return ctrl.Result{}, initErr
+	}
 
 	// Pipeline is already initialized, no need to re-queue
 	return ctrl.Result{}, nil
 }
 
+// setPipelineConditions sets the conditions of the Pipeline according to its availability,
+// and the error of the initialization, if any.
+// It returns whether any condition has changed.
+func setPipelineConditions(pipeline *windtunnelv1alpha1.Pipeline, initErr error) bool {
+	if pipeline.Status.Availability == "" {
+		message := ""
+		if initErr != nil {
+			message = initErr.Error()
+		}
+		return setProgressingConditions(&pipeline.Status.Conditions, pipeline.Generation, "Initializing", message)
+	}
+	return setReadyConditions(&pipeline.Status.Conditions, pipeline.Generation, conditionReason(string(pipeline.Status.Availability)), "")
+}
+
 // initializeMonitor creates monitoring resources for the Pipeline.
 func (r *PipelineReconciler) initializeMonitor(ctx context.Context, pipeline *windtunnelv1alpha1.Pipeline) error {
 	logger := log.FromContext(ctx)
@@ -142,6 +168,7 @@ func (r *PipelineReconciler) initializeMonitor(ctx context.Context, pipeline *wi
 			return err
 		} else if err == nil {
 			logger.Info("Created ExternalName Service")
+			r.Recorder.Event(pipeline, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created ExternalName Service \"%s\"", service.Name))
 		}
 	}
 
@@ -160,6 +187,7 @@ func (r *PipelineReconciler) initializeMonitor(ctx context.Context, pipeline *wi
 		return err
 	} else if err == nil {
 		logger.Info("Created ServiceMonitor")
+		r.Recorder.Event(pipeline, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created ServiceMonitor \"%s\"", serviceMonitor.Name))
 	}
 
 	// Update the status
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cisco-open/k8s-objectmatcher/patch"
@@ -12,6 +13,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -27,7 +29,8 @@ const (
 // PlantDCoreReconciler reconciles a PlantDCore object
 type PlantDCoreReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=plantdcores,verbs=get;list;watch;create;update;patch;delete
@@ -307,6 +310,7 @@ func (r *PlantDCoreReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		r.setDeploymentComponentStatus(&plantDCore.Status.OpenCostStatus, true, curOpenCostDeployment)
 	}
 
+	setPlantDCoreConditions(plantDCore)
 	if err := r.Status().Update(ctx, plantDCore); err != nil {
 		logger.Error(err, "Cannot update the status")
 		return ctrl.Result{}, err
@@ -348,6 +352,7 @@ func (r *PlantDCoreReconciler) reconcileObject(ctx context.Context, plantDCore *
 				return err
 			}
 			logger.Info(fmt.Sprintf("Created %s", getObjectName(kind, desiredObj)))
+			r.Recorder.Event(plantDCore, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created %s", getObjectName(kind, desiredObj)))
 		}
 
 		return nil
@@ -360,6 +365,7 @@ func (r *PlantDCoreReconciler) reconcileObject(ctx context.Context, plantDCore *
 			return err
 		}
 		logger.Info(fmt.Sprintf("Deleted %s", getObjectName(kind, curObj)))
+		r.Recorder.Event(plantDCore, corev1.EventTypeNormal, eventReasonDeleted, fmt.Sprintf("Deleted %s", getObjectName(kind, curObj)))
 		return nil
 	}
 
@@ -393,6 +399,7 @@ func (r *PlantDCoreReconciler) reconcileObject(ctx context.Context, plantDCore *
 		return err
 	}
 	logger.Info(fmt.Sprintf("Updated %s", getObjectName(kind, desiredObj)))
+	r.Recorder.Event(plantDCore, corev1.EventTypeNormal, eventReasonUpdated, fmt.Sprintf("Updated %s", getObjectName(kind, desiredObj)))
 
 	return nil
 }
@@ -453,6 +460,37 @@ func getObjectName(kind string, obj client.Object) string {
 	return fmt.Sprintf("%s/%s/%s", kind, obj.GetNamespace(), obj.GetName())
 }
 
+// setPlantDCoreConditions sets the conditions of the PlantDCore according to the status of its components.
+func setPlantDCoreConditions(plantDCore *windtunnelv1alpha1.PlantDCore) {
+	components := []struct {
+		name   string
+		status *windtunnelv1alpha1.ComponentStatus
+	}{
+		{"PlantD-Proxy", &plantDCore.Status.ProxyStatus},
+		{"PlantD-Studio", &plantDCore.Status.StudioStatus},
+		{"Prometheus", &plantDCore.Status.PrometheusStatus},
+		{"Thanos-Store", &plantDCore.Status.ThanosStoreStatus},
+		{"Thanos-Compactor", &plantDCore.Status.ThanosCompactorStatus},
+		{"Thanos-Querier", &plantDCore.Status.ThanosQuerierStatus},
+		{"Redis", &plantDCore.Status.RedisStatus},
+		{"OpenCost", &plantDCore.Status.OpenCostStatus},
+	}
+	var notReady []string
+	for _, component := range components {
+		if component.status.Text == windtunnelv1alpha1.ComponentNotReady {
+			notReady = append(notReady, component.name)
+		}
+	}
+
+	if len(notReady) != 0 {
+		setProgressingConditions(&plantDCore.Status.Conditions, plantDCore.Generation, "ComponentsNotReady",
+			fmt.Sprintf("Components not ready: %s", strings.Join(notReady, ", ")),
+		)
+	} else {
+		setReadyConditions(&plantDCore.Status.Conditions, plantDCore.Generation, windtunnelv1alpha1.ReasonReconciled, "")
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *PlantDCoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
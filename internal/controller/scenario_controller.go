@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+)
+
+const (
+	// scenarioSchemaIndexKey indexes Scenarios by the names of the Schemas of their tasks.
+	scenarioSchemaIndexKey = ".spec.tasks.name"
+)
+
+// ScenarioReconciler reconciles a Scenario object
+type ScenarioReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=scenarios,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=scenarios/status,verbs=get;update;patch
+//
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=schemas,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.5/pkg/reconcile
+func (r *ScenarioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the requested Scenario
+	scenario := &windtunnelv1alpha1.Scenario{}
+	if err := r.Get(ctx, req.NamespacedName, scenario); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to fetch Scenario")
+		return ctrl.Result{}, err
+	}
+
+	// Check the Schemas of the tasks
+	var unresolved []string
+	for _, task := range scenario.Spec.Tasks {
+		schemaName := types.NamespacedName{
+			Namespace: scenario.Namespace,
+			Name:      task.Name,
+		}
+		if err := r.Get(ctx, schemaName, &windtunnelv1alpha1.Schema{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				logger.Error(err, fmt.Sprintf("Cannot get Schema \"%s\"", schemaName))
+				return ctrl.Result{}, err
+			}
+			unresolved = append(unresolved, fmt.Sprintf("Schema \"%s\"", schemaName))
+		}
+	}
+
+	var changed bool
+	if len(unresolved) != 0 {
+		message := fmt.Sprintf("Cannot find %s", strings.Join(unresolved, ", "))
+		changed = setReferenceNotFound(&scenario.Status.Conditions, scenario.Generation, message)
+		changed = setDegradedConditions(&scenario.Status.Conditions, scenario.Generation, windtunnelv1alpha1.ReasonReferenceNotFound, message) || changed
+	} else {
+		changed = setReferencesResolved(&scenario.Status.Conditions, scenario.Generation)
+		changed = setValidatedConditions(&scenario.Status.Conditions, scenario.Generation, windtunnelv1alpha1.ReasonInvalidSpec, checkScenario(scenario)) || changed
+	}
+
+	if changed {
+		if err := r.Status().Update(ctx, scenario); err != nil {
+			logger.Error(err, "Cannot update the status")
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// checkScenario checks that the ranges and months of the tasks in the Scenario are valid.
+func checkScenario(scenario *windtunnelv1alpha1.Scenario) error {
+	for _, task := range scenario.Spec.Tasks {
+		if task.SendingDevices.Min > task.SendingDevices.Max {
+			return fmt.Errorf("task \"%s\": sendingDevices.min must not be greater than sendingDevices.max", task.Name)
+		}
+		if task.PushFrequencyPerMonth.Min > task.PushFrequencyPerMonth.Max {
+			return fmt.Errorf("task \"%s\": pushFrequencyPerMonth.min must not be greater than pushFrequencyPerMonth.max", task.Name)
+		}
+		for _, month := range task.MonthsRelevant {
+			if month < 1 || month > 12 {
+				return fmt.Errorf("task \"%s\": invalid month %d in monthsRelevant", task.Name, month)
+			}
+		}
+	}
+	return nil
+}
+
+// findScenariosForSchema maps a Schema to the Scenarios using it in their tasks.
+func (r *ScenarioReconciler) findScenariosForSchema(ctx context.Context, schema client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	scenarioList := &windtunnelv1alpha1.ScenarioList{}
+	if err := r.List(ctx, scenarioList,
+		client.InNamespace(schema.GetNamespace()),
+		client.MatchingFields{scenarioSchemaIndexKey: schema.GetName()},
+	); err != nil {
+		logger.Error(err, fmt.Sprintf("Cannot list Scenarios by index \"%s\"", scenarioSchemaIndexKey))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(scenarioList.Items))
+	for i, scenario := range scenarioList.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: scenario.Namespace,
+				Name:      scenario.Name,
+			},
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScenarioReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &windtunnelv1alpha1.Scenario{}, scenarioSchemaIndexKey, func(obj client.Object) []string {
+		scenario := obj.(*windtunnelv1alpha1.Scenario)
+		schemaNames := make([]string, len(scenario.Spec.Tasks))
+		for i, task := range scenario.Spec.Tasks {
+			schemaNames[i] = task.Name
+		}
+		return schemaNames
+	}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&windtunnelv1alpha1.Scenario{}).
+		Watches(&windtunnelv1alpha1.Schema{}, handler.EnqueueRequestsFromMapFunc(r.findScenariosForSchema)).
+		Complete(r)
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/datagen"
+)
+
+const (
+	// schemaReferenceIndexKey indexes Schemas by the names of the other Schemas referenced by their columns.
+	schemaReferenceIndexKey = ".spec.columns.reference.schema"
+)
+
+// SchemaReconciler reconciles a Schema object
+type SchemaReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=schemas,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=schemas/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.5/pkg/reconcile
+func (r *SchemaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the requested Schema
+	schema := &windtunnelv1alpha1.Schema{}
+	if err := r.Get(ctx, req.NamespacedName, schema); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to fetch Schema")
+		return ctrl.Result{}, err
+	}
+
+	// Check the columns referenced in other Schemas, references within the Schema are checked by the webhook
+	var unresolved []string
+	for _, column := range schema.Spec.Columns {
+		if column.Reference == nil || column.Reference.Schema == schema.Name {
+			continue
+		}
+		referencedSchema := &windtunnelv1alpha1.Schema{}
+		referencedSchemaName := types.NamespacedName{
+			Namespace: schema.Namespace,
+			Name:      column.Reference.Schema,
+		}
+		if err := r.Get(ctx, referencedSchemaName, referencedSchema); err != nil {
+			if !apierrors.IsNotFound(err) {
+				logger.Error(err, fmt.Sprintf("Cannot get Schema \"%s\"", referencedSchemaName))
+				return ctrl.Result{}, err
+			}
+			unresolved = append(unresolved, fmt.Sprintf("Schema \"%s\"", referencedSchemaName))
+		} else if !schemaHasColumn(referencedSchema, column.Reference.Column) {
+			unresolved = append(unresolved, fmt.Sprintf("column \"%s\"", datagen.GetReferenceKey(column.Reference)))
+		}
+	}
+
+	var changed bool
+	if len(unresolved) != 0 {
+		message := fmt.Sprintf("Cannot find %s", strings.Join(unresolved, ", "))
+		changed = setReferenceNotFound(&schema.Status.Conditions, schema.Generation, message)
+		changed = setDegradedConditions(&schema.Status.Conditions, schema.Generation, windtunnelv1alpha1.ReasonReferenceNotFound, message) || changed
+	} else {
+		changed = setReferencesResolved(&schema.Status.Conditions, schema.Generation)
+		_, err := datagen.SortColumnsByDependency(schema)
+		changed = setValidatedConditions(&schema.Status.Conditions, schema.Generation, windtunnelv1alpha1.ReasonInvalidSpec, err) || changed
+	}
+
+	if changed {
+		if err := r.Status().Update(ctx, schema); err != nil {
+			logger.Error(err, "Cannot update the status")
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// schemaHasColumn checks whether the Schema has a column with the given name.
+func schemaHasColumn(schema *windtunnelv1alpha1.Schema, name string) bool {
+	for _, column := range schema.Spec.Columns {
+		if column.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// findSchemasForSchema maps a Schema to the other Schemas referencing its columns, so that their references are
+// resolved again when the Schema is created, changed, or deleted.
+func (r *SchemaReconciler) findSchemasForSchema(ctx context.Context, schema client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	schemaList := &windtunnelv1alpha1.SchemaList{}
+	if err := r.List(ctx, schemaList,
+		client.InNamespace(schema.GetNamespace()),
+		client.MatchingFields{schemaReferenceIndexKey: schema.GetName()},
+	); err != nil {
+		logger.Error(err, fmt.Sprintf("Cannot list Schemas by index \"%s\"", schemaReferenceIndexKey))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(schemaList.Items))
+	for i, referencingSchema := range schemaList.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: referencingSchema.Namespace,
+				Name:      referencingSchema.Name,
+			},
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SchemaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &windtunnelv1alpha1.Schema{}, schemaReferenceIndexKey, func(obj client.Object) []string {
+		schema := obj.(*windtunnelv1alpha1.Schema)
+		var schemaNames []string
+		for _, column := range schema.Spec.Columns {
+			if column.Reference != nil && column.Reference.Schema != "" && column.Reference.Schema != schema.Name {
+				schemaNames = append(schemaNames, column.Reference.Schema)
+			}
+		}
+		return schemaNames
+	}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&windtunnelv1alpha1.Schema{}).
+		Watches(&windtunnelv1alpha1.Schema{}, handler.EnqueueRequestsFromMapFunc(r.findSchemasForSchema)).
+		Complete(r)
+}
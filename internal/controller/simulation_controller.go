@@ -6,9 +6,11 @@ import (
 	"time"
 
 	kbatch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -20,13 +22,14 @@ import (
 )
 
 const (
-	simulationPollingInterval = 5 * time.Second
+	simulationPollingInterval = 30 * time.Second
 )
 
 // SimulationReconciler reconciles a Simulation object
 type SimulationReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=simulations,verbs=get;list;watch;create;update;patch;delete
@@ -63,13 +66,15 @@ func (r *SimulationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	jobStatus := simulation.Status.JobStatus
 	if simulation.Status.JobStatus == "" {
 		result, err := r.reconcileCreated(ctx, simulation)
 		if err == nil {
-			if err := r.Status().Update(ctx, simulation); err != nil {
+			if err := r.updateStatus(ctx, simulation); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
+			r.recordTransition(simulation, jobStatus)
 		}
 		return result, err
 	}
@@ -77,10 +82,12 @@ func (r *SimulationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if simulation.Status.JobStatus == windtunnelv1alpha1.SimulationRunning {
 		result, err := r.reconcileRunning(ctx, simulation)
 		if err == nil {
-			if err := r.Status().Update(ctx, simulation); err != nil {
+			if err := r.updateStatus(ctx, simulation); err != nil {
 				logger.Error(err, "Cannot update the status")
 				return ctrl.Result{}, err
 			}
+			r.recordTransition(simulation, jobStatus)
+			r.observeDuration(ctx, simulation)
 		}
 		return result, err
 	}
@@ -88,6 +95,26 @@ func (r *SimulationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
+// updateStatus sets the conditions of the Simulation according to its JobStatus, and updates the status.
+func (r *SimulationReconciler) updateStatus(ctx context.Context, simulation *windtunnelv1alpha1.Simulation) error {
+	switch simulation.Status.JobStatus {
+	case windtunnelv1alpha1.SimulationRunning:
+		setProgressingConditions(&simulation.Status.Conditions, simulation.Generation, conditionReason(string(simulation.Status.JobStatus)), "")
+	case windtunnelv1alpha1.SimulationCompleted:
+		setReadyConditions(&simulation.Status.Conditions, simulation.Generation, windtunnelv1alpha1.ReasonCompleted, "")
+	case windtunnelv1alpha1.SimulationFailed:
+		setDegradedConditions(&simulation.Status.Conditions, simulation.Generation, windtunnelv1alpha1.ReasonFailed, simulation.Status.Error)
+	}
+	return r.Status().Update(ctx, simulation)
+}
+
+// recordTransition emits an event if the JobStatus of the Simulation has changed from the previous one.
+func (r *SimulationReconciler) recordTransition(simulation *windtunnelv1alpha1.Simulation, previous windtunnelv1alpha1.SimulationJobStatus) {
+	recordJobStatusTransition(r.Recorder, simulation, string(previous), string(simulation.Status.JobStatus),
+		string(windtunnelv1alpha1.SimulationFailed), simulation.Status.Error,
+	)
+}
+
 // reconcileCreated reconciles the DigitalTwin when it is created.
 func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation *windtunnelv1alpha1.Simulation) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -104,6 +131,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 			logger.Error(err, fmt.Sprintf("Cannot get DigitalTwin \"%s\"", digitalTwinName))
 			simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 			simulation.Status.Error = fmt.Sprintf("Cannot find DigitalTwin \"%s\": %s", digitalTwinName, err)
+			setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 			return ctrl.Result{}, nil
 		}
 	}
@@ -118,6 +146,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 		logger.Error(err, fmt.Sprintf("Cannot get TrafficModel \"%s\"", trafficModelName))
 		simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 		simulation.Status.Error = fmt.Sprintf("Cannot find TrafficModel \"%s\": %s", trafficModelName, err)
+		setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 		return ctrl.Result{}, nil
 	}
 
@@ -135,6 +164,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 				logger.Error(err, fmt.Sprintf("Cannot get NetCost \"%s\"", netCostName))
 				simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 				simulation.Status.Error = fmt.Sprintf("Cannot find NetCost \"%s\": %s", netCostName, err)
+				setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 				return ctrl.Result{}, nil
 			}
 		}
@@ -149,6 +179,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 			logger.Error(err, fmt.Sprintf("Cannot get Scenario \"%s\"", scenarioName))
 			simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 			simulation.Status.Error = fmt.Sprintf("Cannot find Scenario \"%s\": %s", scenarioName, err)
+			setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 			return ctrl.Result{}, nil
 		}
 
@@ -177,6 +208,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 				logger.Error(err, fmt.Sprintf("Cannot get Experiment \"%s\"", experimentName))
 				simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 				simulation.Status.Error = fmt.Sprintf("Cannot find Experiment \"%s\": %s", experimentName, err)
+				setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 				return ctrl.Result{}, nil
 			}
 			experimentList.Items = append(experimentList.Items, *experiment)
@@ -192,6 +224,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 			logger.Error(err, fmt.Sprintf("Cannot get Pipeline \"%s\"", pipelineName))
 			simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 			simulation.Status.Error = fmt.Sprintf("Cannot find Pipeline \"%s\": %s", pipelineName, err)
+			setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 			return ctrl.Result{}, nil
 		}
 
@@ -217,6 +250,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 						logger.Error(err, fmt.Sprintf("Cannot get DataSet \"%s\"", dataSetName))
 						simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 						simulation.Status.Error = fmt.Sprintf("Cannot find DataSet \"%s\": %s", dataSetName, err)
+						setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 						return ctrl.Result{}, nil
 					}
 					dataSetList.Items = append(dataSetList.Items, *dataSet)
@@ -231,6 +265,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 					logger.Error(err, fmt.Sprintf("Cannot get LoadPattern \"%s\"", loadPatternName))
 					simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 					simulation.Status.Error = fmt.Sprintf("Cannot find LoadPattern \"%s\": %s", loadPatternName, err)
+					setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 					return ctrl.Result{}, nil
 				}
 				loadPatternList.Items = append(loadPatternList.Items, *loadPattern)
@@ -262,6 +297,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 				logger.Error(err, fmt.Sprintf("Cannot get NetCost \"%s\"", netCostName))
 				simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 				simulation.Status.Error = fmt.Sprintf("Cannot find NetCost \"%s\": %s", netCostName, err)
+				setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 				return ctrl.Result{}, nil
 			}
 		}
@@ -276,6 +312,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 			logger.Error(err, fmt.Sprintf("Cannot get Scenario \"%s\"", scenarioName))
 			simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 			simulation.Status.Error = fmt.Sprintf("Cannot find Scenario \"%s\": %s", scenarioName, err)
+			setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 			return ctrl.Result{}, nil
 		}
 
@@ -289,6 +326,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 			logger.Error(err, fmt.Sprintf("Cannot get original DataSet \"%s\"", originalDataSetName))
 			simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 			simulation.Status.Error = fmt.Sprintf("Cannot find original DataSet \"%s\": %s", originalDataSetName, err)
+			setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 			return ctrl.Result{}, nil
 		}
 
@@ -302,6 +340,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 			logger.Error(err, fmt.Sprintf("Cannot get Pipeline \"%s\"", pipelineName))
 			simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 			simulation.Status.Error = fmt.Sprintf("Cannot find Pipeline \"%s\": %s", pipelineName, err)
+			setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 			return ctrl.Result{}, nil
 		}
 
@@ -319,6 +358,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 				logger.Error(err, fmt.Sprintf("Cannot get bias DataSet \"%s\"", dataSetName))
 				simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 				simulation.Status.Error = fmt.Sprintf("Cannot find bias DataSet \"%s\": %s", dataSetName, err)
+				setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 				return ctrl.Result{}, nil
 			}
 			dataSetList.Items = append(dataSetList.Items, *dataSet)
@@ -332,6 +372,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 				logger.Error(err, fmt.Sprintf("Cannot get bias Experiment \"%s\"", experimentName))
 				simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 				simulation.Status.Error = fmt.Sprintf("Cannot find bias Experiment \"%s\": %s", experimentName, err)
+				setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 				return ctrl.Result{}, nil
 			}
 			experimentList.Items = append(experimentList.Items, *experiment)
@@ -345,6 +386,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 			logger.Error(err, fmt.Sprintf("Cannot get bias LoadPattern \"%s\"", loadPatternName))
 			simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
 			simulation.Status.Error = fmt.Sprintf("Cannot find bias LoadPattern \"%s\": %s", loadPatternName, err)
+			setReferenceNotFound(&simulation.Status.Conditions, simulation.Generation, simulation.Status.Error)
 			return ctrl.Result{}, nil
 		}
 		loadPatternList.Items = append(loadPatternList.Items, *loadPattern)
@@ -363,6 +405,8 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 		}
 	}
 
+	setReferencesResolved(&simulation.Status.Conditions, simulation.Generation)
+
 	if err := ctrl.SetControllerReference(simulation, job, r.Scheme); err != nil {
 		logger.Error(err, "Cannot set controller reference for Job")
 		simulation.Status.JobStatus = windtunnelv1alpha1.SimulationFailed
@@ -376,6 +420,7 @@ func (r *SimulationReconciler) reconcileCreated(ctx context.Context, simulation
 		return ctrl.Result{}, nil
 	} else if err == nil {
 		logger.Info("Created Job")
+		r.Recorder.Event(simulation, corev1.EventTypeNormal, eventReasonCreated, fmt.Sprintf("Created Job \"%s\"", job.Name))
 	}
 
 	simulation.Status.JobStatus = windtunnelv1alpha1.SimulationRunning
@@ -416,9 +461,36 @@ func (r *SimulationReconciler) reconcileRunning(ctx context.Context, simulation
 	return ctrl.Result{RequeueAfter: simulationPollingInterval}, nil
 }
 
+// observeDuration observes the run time of the Job once the Simulation is finished.
+// It is called only after the status is updated, so that the Job is not observed again on conflicts.
+func (r *SimulationReconciler) observeDuration(ctx context.Context, simulation *windtunnelv1alpha1.Simulation) {
+	var result string
+	switch simulation.Status.JobStatus {
+	case windtunnelv1alpha1.SimulationCompleted:
+		result = metricsResultSuccess
+	case windtunnelv1alpha1.SimulationFailed:
+		result = metricsResultFailure
+	default:
+		return
+	}
+
+	// The Job is lost if it cannot be found, so there is nothing to observe
+	job := &kbatch.Job{}
+	jobName := types.NamespacedName{
+		Namespace: simulation.Namespace,
+		Name:      utils.GetSimulationJobName(simulation.Name),
+	}
+	if err := r.Get(ctx, jobName, job); err != nil {
+		return
+	}
+	observeJobDuration(simulationDurationSeconds, job, simulation.Namespace, result)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SimulationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Changes of the Job trigger the state transitions, polling only serves as a fallback
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&windtunnelv1alpha1.Simulation{}).
+		Owns(&kbatch.Job{}).
 		Complete(r)
 }
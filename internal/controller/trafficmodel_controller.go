@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+)
+
+// TrafficModelReconciler reconciles a TrafficModel object
+type TrafficModelReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=trafficmodels,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=windtunnel.plantd.org,resources=trafficmodels/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.16.5/pkg/reconcile
+func (r *TrafficModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the requested TrafficModel
+	trafficModel := &windtunnelv1alpha1.TrafficModel{}
+	if err := r.Get(ctx, req.NamespacedName, trafficModel); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to fetch TrafficModel")
+		return ctrl.Result{}, err
+	}
+
+	// The configuration is passed as is to the simulations, which expect valid JSON
+	checkErr := checkTrafficModel(trafficModel)
+	if setValidatedConditions(&trafficModel.Status.Conditions, trafficModel.Generation, windtunnelv1alpha1.ReasonInvalidSpec, checkErr) {
+		if err := r.Status().Update(ctx, trafficModel); err != nil {
+			logger.Error(err, "Cannot update the status")
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// checkTrafficModel checks that the configuration of the TrafficModel is valid JSON.
+func checkTrafficModel(trafficModel *windtunnelv1alpha1.TrafficModel) error {
+	if !json.Valid([]byte(trafficModel.Spec.Config)) {
+		return errors.New("config is not valid JSON")
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TrafficModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&windtunnelv1alpha1.TrafficModel{}).
+		Complete(r)
+}
@@ -0,0 +1,134 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupCapacitySearchWebhookWithManager registers the webhooks for CapacitySearch in the manager.
+func SetupCapacitySearchWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&windtunnelv1alpha1.CapacitySearch{}).
+		WithValidator(&CapacitySearchCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-windtunnel-plantd-org-v1alpha1-capacitysearch,mutating=false,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=capacitysearches,verbs=create;update,versions=v1alpha1,name=vcapacitysearch.kb.io,admissionReviewVersions=v1
+
+// CapacitySearchCustomValidator validates CapacitySearch.
+type CapacitySearchCustomValidator struct {
+	Client client.Client
+}
+
+var _ admission.CustomValidator = &CapacitySearchCustomValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *CapacitySearchCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	capacitySearch, ok := obj.(*windtunnelv1alpha1.CapacitySearch)
+	if !ok {
+		return nil, fmt.Errorf("expected a CapacitySearch object but got %T", obj)
+	}
+	return v.validate(ctx, capacitySearch)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *CapacitySearchCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldCapacitySearch, ok := oldObj.(*windtunnelv1alpha1.CapacitySearch)
+	if !ok {
+		return nil, fmt.Errorf("expected a CapacitySearch object but got %T", oldObj)
+	}
+	capacitySearch, ok := newObj.(*windtunnelv1alpha1.CapacitySearch)
+	if !ok {
+		return nil, fmt.Errorf("expected a CapacitySearch object but got %T", newObj)
+	}
+	// Skip the validation if the spec is unchanged, e.g., when only the finalizers are updated,
+	// so that changes in the referenced objects never block the reconciliation
+	if equality.Semantic.DeepEqual(oldCapacitySearch.Spec, capacitySearch.Spec) {
+		return nil, nil
+	}
+	return v.validate(ctx, capacitySearch)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *CapacitySearchCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks the range of target rates and the SLO of the CapacitySearch, and the endpoint of the Pipeline it
+// references. A Pipeline or DataSet that does not exist yet only produces a warning, as it may be created later.
+func (v *CapacitySearchCustomValidator) validate(ctx context.Context, capacitySearch *windtunnelv1alpha1.CapacitySearch) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if capacitySearch.Spec.MinRate > capacitySearch.Spec.MaxRate {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("minRate"), capacitySearch.Spec.MinRate, "must not be greater than maxRate"))
+	}
+
+	if capacitySearch.Spec.Duration != nil && capacitySearch.Spec.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("duration"), capacitySearch.Spec.Duration.Duration.String(), "must be positive"))
+	}
+	if capacitySearch.Spec.DrainingTime != nil && capacitySearch.Spec.DrainingTime.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("drainingTime"), capacitySearch.Spec.DrainingTime.Duration.String(), "must not be negative"))
+	}
+	if capacitySearch.Spec.MaxVUs != 0 && capacitySearch.Spec.MaxVUs < capacitySearch.Spec.PreAllocatedVUs {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxVUs"), capacitySearch.Spec.MaxVUs, "must not be less than preAllocatedVUs"))
+	}
+
+	allErrs = append(allErrs, validateSLO(specPath.Child("slo"), &capacitySearch.Spec.SLO)...)
+
+	// Check the endpoint of the Pipeline
+	if capacitySearch.Spec.PipelineRef == nil || capacitySearch.Spec.PipelineRef.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("pipelineRef", "name"), "must reference a Pipeline"))
+	} else {
+		pipeline := &windtunnelv1alpha1.Pipeline{}
+		pipelineName := types.NamespacedName{
+			Namespace: capacitySearch.Namespace,
+			Name:      capacitySearch.Spec.PipelineRef.Name,
+		}
+		if err := v.Client.Get(ctx, pipelineName, pipeline); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			warnings = append(warnings, fmt.Sprintf("Pipeline \"%s\" does not exist yet", pipelineName))
+		} else if pipelineEndpoint := getPipelineEndpoint(pipeline, capacitySearch.Spec.EndpointName); pipelineEndpoint == nil {
+			allErrs = append(allErrs, field.NotFound(specPath.Child("endpointName"), capacitySearch.Spec.EndpointName))
+		} else if !hasPipelineEndpointProtocol(pipelineEndpoint) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("endpointName"), capacitySearch.Spec.EndpointName,
+				fmt.Sprintf("unspecified protocol in endpoint of Pipeline \"%s\"", pipeline.Name),
+			))
+		}
+	}
+
+	// Check the DataSet
+	if capacitySearch.Spec.DataSetRef == nil || capacitySearch.Spec.DataSetRef.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("dataSetRef", "name"), "must reference a DataSet"))
+	} else {
+		dataSetName := types.NamespacedName{
+			Namespace: capacitySearch.Namespace,
+			Name:      capacitySearch.Spec.DataSetRef.Name,
+		}
+		if err := v.Client.Get(ctx, dataSetName, &windtunnelv1alpha1.DataSet{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			warnings = append(warnings, fmt.Sprintf("DataSet \"%s\" does not exist yet", dataSetName))
+		}
+	}
+
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(windtunnelv1alpha1.GroupVersion.WithKind("CapacitySearch").GroupKind(), capacitySearch.Name, allErrs)
+	}
+	return warnings, nil
+}
@@ -0,0 +1,151 @@
+package v1alpha1
+
+import (
+	"strconv"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// getPipelineEndpoint finds the PipelineEndpoint with the given name in the Pipeline.
+func getPipelineEndpoint(pipeline *windtunnelv1alpha1.Pipeline, name string) *windtunnelv1alpha1.PipelineEndpoint {
+	for _, pipelineEndpoint := range pipeline.Spec.PipelineEndpoints {
+		if pipelineEndpoint.Name == name {
+			return &pipelineEndpoint
+		}
+	}
+	return nil
+}
+
+// getPipelineEndpointProtocol returns the protocol used by the PipelineEndpoint, in the same order of precedence
+// as the Experiment controller. It returns an empty string if no protocol is specified.
+func getPipelineEndpointProtocol(pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint) windtunnelv1alpha1.EndpointProtocol {
+	switch {
+	case pipelineEndpoint.HTTP != nil && pipelineEndpoint.HTTP.URL != "" && pipelineEndpoint.HTTP.Method != "":
+		return windtunnelv1alpha1.EndpointProtocolHTTP
+	case pipelineEndpoint.GRPC != nil && pipelineEndpoint.GRPC.Address != "" && pipelineEndpoint.GRPC.Method != "":
+		return windtunnelv1alpha1.EndpointProtocolGRPC
+	case pipelineEndpoint.Kafka != nil && len(pipelineEndpoint.Kafka.Brokers) > 0 && pipelineEndpoint.Kafka.Topic != "":
+		return windtunnelv1alpha1.EndpointProtocolKafka
+	case pipelineEndpoint.MQTT != nil && pipelineEndpoint.MQTT.URL != "" && pipelineEndpoint.MQTT.Topic != "":
+		return windtunnelv1alpha1.EndpointProtocolMQTT
+	case pipelineEndpoint.WebSocket != nil && pipelineEndpoint.WebSocket.URL != "":
+		return windtunnelv1alpha1.EndpointProtocolWebSocket
+	}
+	return ""
+}
+
+// hasPipelineEndpointProtocol checks if the PipelineEndpoint has a protocol configured.
+func hasPipelineEndpointProtocol(pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint) bool {
+	return getPipelineEndpointProtocol(pipelineEndpoint) != ""
+}
+
+// hasColumn checks whether the Schema has a column with the given name.
+func hasColumn(schema *windtunnelv1alpha1.Schema, name string) bool {
+	for _, column := range schema.Spec.Columns {
+		if column.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getColumnNames returns the names of the columns in the Schema.
+func getColumnNames(schema *windtunnelv1alpha1.Schema) []string {
+	columnNames := make([]string, len(schema.Spec.Columns))
+	for i, column := range schema.Spec.Columns {
+		columnNames[i] = column.Name
+	}
+	return columnNames
+}
+
+// validateNaturalIntRange checks that the lower bound of the NaturalIntRange does not exceed the upper bound.
+func validateNaturalIntRange(fldPath *field.Path, r windtunnelv1alpha1.NaturalIntRange) field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Min > r.Max {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("min"), r.Min, "must not be greater than max"))
+	}
+	return allErrs
+}
+
+// validateSecretKeySelector checks that the SecretKeySelector references a key of a Secret.
+func validateSecretKeySelector(fldPath *field.Path, selector *corev1.SecretKeySelector) field.ErrorList {
+	var allErrs field.ErrorList
+	if selector.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "must reference a Secret"))
+	}
+	if selector.Key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), "must reference a key of the Secret"))
+	}
+	return allErrs
+}
+
+// validateSLO checks the thresholds and assertions of the SLOSpec.
+func validateSLO(fldPath *field.Path, slo *windtunnelv1alpha1.SLOSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if slo.LatencyP95 != nil && slo.LatencyP95.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("latencyP95"), slo.LatencyP95.Duration.String(), "must be positive"))
+	}
+	ratios := []struct {
+		name  string
+		value string
+	}{
+		{"errorRate", slo.ErrorRate},
+		{"minRateRatio", slo.MinRateRatio},
+	}
+	for _, ratio := range ratios {
+		if ratio.value == "" {
+			continue
+		}
+		if value, err := strconv.ParseFloat(ratio.value, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(ratio.name), ratio.value, "must be a float number"))
+		} else if value < 0 || value > 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(ratio.name), ratio.value, "must be between 0 and 1"))
+		}
+	}
+	assertionNames := make(map[string]bool, len(slo.Assertions))
+	for i, assertion := range slo.Assertions {
+		assertionPath := fldPath.Child("assertions").Index(i)
+		if assertionNames[assertion.Name] {
+			allErrs = append(allErrs, field.Duplicate(assertionPath.Child("name"), assertion.Name))
+		}
+		assertionNames[assertion.Name] = true
+		if _, err := strconv.ParseFloat(assertion.Value, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(assertionPath.Child("value"), assertion.Value, "must be a float number"))
+		}
+	}
+	return allErrs
+}
+
+// validateRegressionTolerance checks the tolerances and the significance level of the RegressionTolerance.
+func validateRegressionTolerance(fldPath *field.Path, regressionTolerance *windtunnelv1alpha1.RegressionTolerance) field.ErrorList {
+	var allErrs field.ErrorList
+	tolerances := []struct {
+		name  string
+		value string
+	}{
+		{"latency", regressionTolerance.Latency},
+		{"achievedRate", regressionTolerance.AchievedRate},
+		{"errorRate", regressionTolerance.ErrorRate},
+	}
+	for _, tolerance := range tolerances {
+		if tolerance.value == "" {
+			continue
+		}
+		if value, err := strconv.ParseFloat(tolerance.value, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(tolerance.name), tolerance.value, "must be a float number"))
+		} else if value < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(tolerance.name), tolerance.value, "must not be negative"))
+		}
+	}
+	if regressionTolerance.SignificanceLevel != "" {
+		if value, err := strconv.ParseFloat(regressionTolerance.SignificanceLevel, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("significanceLevel"), regressionTolerance.SignificanceLevel, "must be a float number"))
+		} else if value <= 0 || value >= 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("significanceLevel"), regressionTolerance.SignificanceLevel, "must be between 0 and 1, exclusive"))
+		}
+	}
+	return allErrs
+}
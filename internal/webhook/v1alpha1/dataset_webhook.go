@@ -0,0 +1,184 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/config"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/datagen"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var (
+	dataSetDefaultParallelism = config.GetInt32("dataGenerator.defaultParallelism")
+)
+
+// SetupDataSetWebhookWithManager registers the webhooks for DataSet in the manager.
+func SetupDataSetWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&windtunnelv1alpha1.DataSet{}).
+		WithDefaulter(&DataSetCustomDefaulter{}).
+		WithValidator(&DataSetCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-windtunnel-plantd-org-v1alpha1-dataset,mutating=true,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=datasets,verbs=create;update,versions=v1alpha1,name=mdataset.kb.io,admissionReviewVersions=v1
+
+// DataSetCustomDefaulter sets default values on DataSet.
+type DataSetCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &DataSetCustomDefaulter{}
+
+// Default implements admission.CustomDefaulter.
+func (d *DataSetCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	dataSet, ok := obj.(*windtunnelv1alpha1.DataSet)
+	if !ok {
+		return fmt.Errorf("expected a DataSet object but got %T", obj)
+	}
+
+	if dataSet.Spec.Parallelism == 0 {
+		dataSet.Spec.Parallelism = dataSetDefaultParallelism
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-windtunnel-plantd-org-v1alpha1-dataset,mutating=false,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=datasets,verbs=create;update,versions=v1alpha1,name=vdataset.kb.io,admissionReviewVersions=v1
+
+// DataSetCustomValidator validates DataSet.
+type DataSetCustomValidator struct {
+	Client client.Client
+}
+
+var _ admission.CustomValidator = &DataSetCustomValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *DataSetCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	dataSet, ok := obj.(*windtunnelv1alpha1.DataSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a DataSet object but got %T", obj)
+	}
+	return v.validate(ctx, dataSet)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *DataSetCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldDataSet, ok := oldObj.(*windtunnelv1alpha1.DataSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a DataSet object but got %T", oldObj)
+	}
+	dataSet, ok := newObj.(*windtunnelv1alpha1.DataSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a DataSet object but got %T", newObj)
+	}
+	// Skip the validation if the spec is unchanged, so that changes in the referenced objects
+	// never block the reconciliation
+	if equality.Semantic.DeepEqual(oldDataSet.Spec, dataSet.Spec) {
+		return nil, nil
+	}
+	return v.validate(ctx, dataSet)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *DataSetCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks the output format and the Schemas of the DataSet, using the same operation lookups
+// as the data generator. Schemas that do not exist yet only produce warnings, as they may be created later.
+// References between the Schemas are checked for undefined columns and cycles once all Schemas exist.
+func (v *DataSetCustomValidator) validate(ctx context.Context, dataSet *windtunnelv1alpha1.DataSet) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	// Check the operations used to generate the output files
+	if dataSet.Spec.CompressedFileFormat == "" {
+		if datagen.GetOpLookups(dataSet.Spec.FileFormat) == nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("fileFormat"), dataSet.Spec.FileFormat, "unsupported file format"))
+		}
+	} else {
+		op := fmt.Sprintf("%s@cache", dataSet.Spec.FileFormat)
+		compressionOp := fmt.Sprintf("%s->%s", dataSet.Spec.FileFormat, dataSet.Spec.CompressedFileFormat)
+		if datagen.GetOpLookups(op) == nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("fileFormat"), dataSet.Spec.FileFormat, "unsupported file format"))
+		} else if datagen.GetOpLookups(compressionOp) == nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("compressedFileFormat"), dataSet.Spec.CompressedFileFormat,
+				fmt.Sprintf("cannot compress files of format \"%s\"", dataSet.Spec.FileFormat),
+			))
+		}
+	}
+
+	// Files compressed as a stream are sent as a single request body, so each compressed file must contain
+	// exactly one output file, otherwise it decompresses to the concatenation of several files
+	streamCompressed := dataSet.Spec.CompressedFileFormat == "gzip" || dataSet.Spec.CompressedFileFormat == "zstd"
+	if streamCompressed && !dataSet.Spec.CompressPerSchema {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("compressPerSchema"), dataSet.Spec.CompressPerSchema,
+			fmt.Sprintf("must be true when compressedFileFormat is \"%s\"", dataSet.Spec.CompressedFileFormat),
+		))
+	}
+
+	if dataSet.Spec.Parallelism > dataSet.Spec.NumberOfFiles {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("parallelism"), dataSet.Spec.Parallelism, "must not be greater than numFiles"))
+	}
+
+	schemaNames := make(map[string]bool, len(dataSet.Spec.Schemas))
+	schemaMap := make(map[string]*windtunnelv1alpha1.Schema, len(dataSet.Spec.Schemas))
+	for i, schemaSelector := range dataSet.Spec.Schemas {
+		schemaPath := specPath.Child("schemas").Index(i)
+
+		if schemaNames[schemaSelector.Name] {
+			allErrs = append(allErrs, field.Duplicate(schemaPath.Child("name"), schemaSelector.Name))
+		}
+		schemaNames[schemaSelector.Name] = true
+
+		allErrs = append(allErrs, validateNaturalIntRange(schemaPath.Child("numRecords"), schemaSelector.NumRecords)...)
+		if dataSet.Spec.CompressedFileFormat != "" {
+			allErrs = append(allErrs, validateNaturalIntRange(schemaPath.Child("numFilesPerCompressedFile"), schemaSelector.NumFilesPerCompressedFile)...)
+		}
+		if streamCompressed && (schemaSelector.NumFilesPerCompressedFile.Min != 1 || schemaSelector.NumFilesPerCompressedFile.Max != 1) {
+			allErrs = append(allErrs, field.Invalid(schemaPath.Child("numFilesPerCompressedFile"), schemaSelector.NumFilesPerCompressedFile,
+				fmt.Sprintf("must be exactly 1 when compressedFileFormat is \"%s\"", dataSet.Spec.CompressedFileFormat),
+			))
+		}
+
+		schemaName := types.NamespacedName{
+			Namespace: dataSet.Namespace,
+			Name:      schemaSelector.Name,
+		}
+		schema := &windtunnelv1alpha1.Schema{}
+		if err := v.Client.Get(ctx, schemaName, schema); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			warnings = append(warnings, fmt.Sprintf("Schema \"%s\" does not exist yet", schemaName))
+			continue
+		}
+		schemaMap[schemaSelector.Name] = schema
+	}
+
+	// References between Schemas can only be checked when all of them exist
+	if len(schemaMap) == len(dataSet.Spec.Schemas) {
+		schemaSelectorNames := make([]string, len(dataSet.Spec.Schemas))
+		for i, schemaSelector := range dataSet.Spec.Schemas {
+			schemaSelectorNames[i] = schemaSelector.Name
+		}
+		if _, err := datagen.SortSchemasByDependency(schemaSelectorNames, schemaMap); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("schemas"), schemaSelectorNames, err.Error()))
+		}
+	}
+
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(windtunnelv1alpha1.GroupVersion.WithKind("DataSet").GroupKind(), dataSet.Name, allErrs)
+	}
+	return warnings, nil
+}
@@ -0,0 +1,121 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupDigitalTwinWebhookWithManager registers the webhooks for DigitalTwin in the manager.
+func SetupDigitalTwinWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&windtunnelv1alpha1.DigitalTwin{}).
+		WithDefaulter(&DigitalTwinCustomDefaulter{}).
+		WithValidator(&DigitalTwinCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-windtunnel-plantd-org-v1alpha1-digitaltwin,mutating=true,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=digitaltwins,verbs=create;update,versions=v1alpha1,name=mdigitaltwin.kb.io,admissionReviewVersions=v1
+
+// DigitalTwinCustomDefaulter sets default values on DigitalTwin.
+type DigitalTwinCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &DigitalTwinCustomDefaulter{}
+
+// Default implements admission.CustomDefaulter.
+func (d *DigitalTwinCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	digitalTwin, ok := obj.(*windtunnelv1alpha1.DigitalTwin)
+	if !ok {
+		return fmt.Errorf("expected a DigitalTwin object but got %T", obj)
+	}
+
+	// Experiments default to the namespace of the DigitalTwin
+	for _, experimentRef := range digitalTwin.Spec.Experiments {
+		if experimentRef != nil && experimentRef.Namespace == "" {
+			experimentRef.Namespace = digitalTwin.Namespace
+		}
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-windtunnel-plantd-org-v1alpha1-digitaltwin,mutating=false,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=digitaltwins,verbs=create;update,versions=v1alpha1,name=vdigitaltwin.kb.io,admissionReviewVersions=v1
+
+// DigitalTwinCustomValidator validates DigitalTwin.
+type DigitalTwinCustomValidator struct{}
+
+var _ admission.CustomValidator = &DigitalTwinCustomValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *DigitalTwinCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	digitalTwin, ok := obj.(*windtunnelv1alpha1.DigitalTwin)
+	if !ok {
+		return nil, fmt.Errorf("expected a DigitalTwin object but got %T", obj)
+	}
+	return v.validate(digitalTwin)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *DigitalTwinCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldDigitalTwin, ok := oldObj.(*windtunnelv1alpha1.DigitalTwin)
+	if !ok {
+		return nil, fmt.Errorf("expected a DigitalTwin object but got %T", oldObj)
+	}
+	digitalTwin, ok := newObj.(*windtunnelv1alpha1.DigitalTwin)
+	if !ok {
+		return nil, fmt.Errorf("expected a DigitalTwin object but got %T", newObj)
+	}
+	// Skip the validation if the spec is unchanged, so that objects created before the
+	// validation existed can still be updated
+	if equality.Semantic.DeepEqual(oldDigitalTwin.Spec, digitalTwin.Spec) {
+		return nil, nil
+	}
+	return v.validate(digitalTwin)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *DigitalTwinCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks that the fields required by the type of the DigitalTwin are set.
+func (v *DigitalTwinCustomValidator) validate(digitalTwin *windtunnelv1alpha1.DigitalTwin) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	switch digitalTwin.Spec.DigitalTwinType {
+	case "regular":
+		if len(digitalTwin.Spec.Experiments) == 0 {
+			allErrs = append(allErrs, field.Required(specPath.Child("experiments"), "must be set for regular DigitalTwin"))
+		}
+		for i, experimentRef := range digitalTwin.Spec.Experiments {
+			if experimentRef == nil || experimentRef.Name == "" {
+				allErrs = append(allErrs, field.Required(specPath.Child("experiments").Index(i).Child("name"), ""))
+			}
+		}
+
+	case "schemaaware":
+		if digitalTwin.Spec.DataSet == nil || digitalTwin.Spec.DataSet.Name == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("dataSet", "name"), "must be set for schema-aware DigitalTwin"))
+		}
+		if digitalTwin.Spec.Pipeline == nil || digitalTwin.Spec.Pipeline.Name == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("pipeline", "name"), "must be set for schema-aware DigitalTwin"))
+		}
+		if digitalTwin.Spec.PipelineCapacity <= 0 {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("pipelineCapacity"), digitalTwin.Spec.PipelineCapacity, "must be positive for schema-aware DigitalTwin"))
+		}
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(windtunnelv1alpha1.GroupVersion.WithKind("DigitalTwin").GroupKind(), digitalTwin.Name, allErrs)
+	}
+	return nil, nil
+}
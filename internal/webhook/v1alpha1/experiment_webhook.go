@@ -0,0 +1,226 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupExperimentWebhookWithManager registers the webhooks for Experiment in the manager.
+func SetupExperimentWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&windtunnelv1alpha1.Experiment{}).
+		WithDefaulter(&ExperimentCustomDefaulter{}).
+		WithValidator(&ExperimentCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-windtunnel-plantd-org-v1alpha1-experiment,mutating=true,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=experiments,verbs=create;update,versions=v1alpha1,name=mexperiment.kb.io,admissionReviewVersions=v1
+
+// ExperimentCustomDefaulter sets default values on Experiment.
+type ExperimentCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &ExperimentCustomDefaulter{}
+
+// Default implements admission.CustomDefaulter.
+func (d *ExperimentCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	experiment, ok := obj.(*windtunnelv1alpha1.Experiment)
+	if !ok {
+		return fmt.Errorf("expected an Experiment object but got %T", obj)
+	}
+
+	// LoadPatterns default to the namespace of the Experiment
+	for i := range experiment.Spec.EndpointSpecs {
+		loadPatternRef := experiment.Spec.EndpointSpecs[i].LoadPatternRef
+		if loadPatternRef != nil && loadPatternRef.Namespace == "" {
+			loadPatternRef.Namespace = experiment.Namespace
+		}
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-windtunnel-plantd-org-v1alpha1-experiment,mutating=false,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=experiments,verbs=create;update,versions=v1alpha1,name=vexperiment.kb.io,admissionReviewVersions=v1
+
+// ExperimentCustomValidator validates Experiment.
+type ExperimentCustomValidator struct {
+	Client client.Client
+}
+
+var _ admission.CustomValidator = &ExperimentCustomValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *ExperimentCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	experiment, ok := obj.(*windtunnelv1alpha1.Experiment)
+	if !ok {
+		return nil, fmt.Errorf("expected an Experiment object but got %T", obj)
+	}
+	return v.validate(ctx, experiment)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *ExperimentCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldExperiment, ok := oldObj.(*windtunnelv1alpha1.Experiment)
+	if !ok {
+		return nil, fmt.Errorf("expected an Experiment object but got %T", oldObj)
+	}
+	experiment, ok := newObj.(*windtunnelv1alpha1.Experiment)
+	if !ok {
+		return nil, fmt.Errorf("expected an Experiment object but got %T", newObj)
+	}
+	// Skip the validation if the spec is unchanged, e.g., when only the finalizers are updated,
+	// so that changes in the referenced objects never block the reconciliation
+	if equality.Semantic.DeepEqual(oldExperiment.Spec, experiment.Spec) {
+		return nil, nil
+	}
+	return v.validate(ctx, experiment)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *ExperimentCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks the Experiment against the Pipeline, DataSets, and LoadPatterns it references.
+// Referenced objects that do not exist yet only produce warnings, as they may be created later.
+func (v *ExperimentCustomValidator) validate(ctx context.Context, experiment *windtunnelv1alpha1.Experiment) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	// Fetch the Pipeline used by the Experiment
+	var pipeline *windtunnelv1alpha1.Pipeline
+	if experiment.Spec.PipelineRef == nil || experiment.Spec.PipelineRef.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("pipelineRef", "name"), "must reference a Pipeline"))
+	} else {
+		pipeline = &windtunnelv1alpha1.Pipeline{}
+		pipelineName := types.NamespacedName{
+			Namespace: experiment.Namespace,
+			Name:      experiment.Spec.PipelineRef.Name,
+		}
+		if err := v.Client.Get(ctx, pipelineName, pipeline); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			warnings = append(warnings, fmt.Sprintf("Pipeline \"%s\" does not exist yet", pipelineName))
+			pipeline = nil
+		}
+	}
+
+	endpointNames := make(map[string]bool, len(experiment.Spec.EndpointSpecs))
+	for i, endpointSpec := range experiment.Spec.EndpointSpecs {
+		endpointSpecPath := specPath.Child("endpointSpecs").Index(i)
+
+		// Check the endpoint name
+		if endpointNames[endpointSpec.EndpointName] {
+			allErrs = append(allErrs, field.Duplicate(endpointSpecPath.Child("endpointName"), endpointSpec.EndpointName))
+		}
+		endpointNames[endpointSpec.EndpointName] = true
+		var pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint
+		if pipeline != nil {
+			pipelineEndpoint = getPipelineEndpoint(pipeline, endpointSpec.EndpointName)
+			if pipelineEndpoint == nil {
+				allErrs = append(allErrs, field.NotFound(endpointSpecPath.Child("endpointName"), endpointSpec.EndpointName))
+			} else if !hasPipelineEndpointProtocol(pipelineEndpoint) {
+				allErrs = append(allErrs, field.Invalid(endpointSpecPath.Child("endpointName"), endpointSpec.EndpointName,
+					fmt.Sprintf("unspecified protocol in endpoint of Pipeline \"%s\"", pipeline.Name),
+				))
+			}
+		}
+
+		// Check the data option
+		dataSpec := endpointSpec.DataSpec
+		if dataSpec == nil || (dataSpec.PlainText == "" && (dataSpec.DataSetRef == nil || dataSpec.DataSetRef.Name == "")) {
+			allErrs = append(allErrs, field.Required(endpointSpecPath.Child("dataSpec"), "must set either plainText or dataSetRef"))
+		} else if dataSpec.DataSetRef != nil && dataSpec.DataSetRef.Name != "" {
+			dataSetName := types.NamespacedName{
+				Namespace: experiment.Namespace,
+				Name:      dataSpec.DataSetRef.Name,
+			}
+			dataSet := &windtunnelv1alpha1.DataSet{}
+			if err := v.Client.Get(ctx, dataSetName, dataSet); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				warnings = append(warnings, fmt.Sprintf("DataSet \"%s\" for endpoint \"%s\" does not exist yet",
+					dataSetName, endpointSpec.EndpointName,
+				))
+			} else if pipelineEndpoint != nil && getPipelineEndpointProtocol(pipelineEndpoint) == windtunnelv1alpha1.EndpointProtocolGRPC {
+				// Each record is sent as a gRPC request message, which must be a single JSON object
+				if (dataSet.Spec.FileFormat != "json" && dataSet.Spec.FileFormat != "ndjson") || dataSet.Spec.CompressedFileFormat != "" {
+					allErrs = append(allErrs, field.Invalid(endpointSpecPath.Child("dataSpec", "dataSetRef", "name"), dataSpec.DataSetRef.Name,
+						fmt.Sprintf("gRPC endpoint only supports uncompressed json or ndjson DataSets, got \"%s\" compressed by \"%s\"",
+							dataSet.Spec.FileFormat, dataSet.Spec.CompressedFileFormat,
+						),
+					))
+				}
+			}
+		}
+
+		// Check the LoadPattern
+		loadPatternRef := endpointSpec.LoadPatternRef
+		if loadPatternRef == nil || loadPatternRef.Name == "" {
+			allErrs = append(allErrs, field.Required(endpointSpecPath.Child("loadPatternRef", "name"), "must reference a LoadPattern"))
+		} else {
+			loadPattern := &windtunnelv1alpha1.LoadPattern{}
+			loadPatternName := types.NamespacedName{
+				Namespace: loadPatternRef.Namespace,
+				Name:      loadPatternRef.Name,
+			}
+			if err := v.Client.Get(ctx, loadPatternName, loadPattern); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				warnings = append(warnings, fmt.Sprintf("LoadPattern \"%s\" for endpoint \"%s\" does not exist yet",
+					loadPatternName, endpointSpec.EndpointName,
+				))
+			}
+		}
+	}
+
+	if experiment.Spec.DrainingTime != nil && experiment.Spec.DrainingTime.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("drainingTime"), experiment.Spec.DrainingTime.Duration.String(), "must not be negative"))
+	}
+
+	if experiment.Spec.SLO != nil {
+		allErrs = append(allErrs, validateSLO(specPath.Child("slo"), experiment.Spec.SLO)...)
+	}
+
+	if baselineRef := experiment.Spec.BaselineRef; baselineRef != nil {
+		if baselineRef.Name == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("baselineRef", "name"), "must reference an Experiment"))
+		} else if baselineRef.Name == experiment.Name {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("baselineRef", "name"), baselineRef.Name, "must not reference the Experiment itself"))
+		} else {
+			baselineName := types.NamespacedName{
+				Namespace: experiment.Namespace,
+				Name:      baselineRef.Name,
+			}
+			if err := v.Client.Get(ctx, baselineName, &windtunnelv1alpha1.Experiment{}); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				warnings = append(warnings, fmt.Sprintf("Baseline Experiment \"%s\" does not exist yet", baselineName))
+			}
+		}
+	}
+
+	if experiment.Spec.RegressionTolerance != nil {
+		allErrs = append(allErrs, validateRegressionTolerance(specPath.Child("regressionTolerance"), experiment.Spec.RegressionTolerance)...)
+	}
+
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(windtunnelv1alpha1.GroupVersion.WithKind("Experiment").GroupKind(), experiment.Name, allErrs)
+	}
+	return warnings, nil
+}
@@ -0,0 +1,176 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// loadPatternDefaultTimeUnit is the default value of the `timeUnit` field, same as the K6 default.
+	loadPatternDefaultTimeUnit = "1s"
+	// loadPatternDefaultMaxDuration is the default value of the `maxDuration` field, same as the K6 default.
+	loadPatternDefaultMaxDuration = "10m"
+)
+
+// SetupLoadPatternWebhookWithManager registers the webhooks for LoadPattern in the manager.
+func SetupLoadPatternWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&windtunnelv1alpha1.LoadPattern{}).
+		WithDefaulter(&LoadPatternCustomDefaulter{}).
+		WithValidator(&LoadPatternCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-windtunnel-plantd-org-v1alpha1-loadpattern,mutating=true,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=loadpatterns,verbs=create;update,versions=v1alpha1,name=mloadpattern.kb.io,admissionReviewVersions=v1
+
+// LoadPatternCustomDefaulter sets default values on LoadPattern.
+type LoadPatternCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &LoadPatternCustomDefaulter{}
+
+// Default implements admission.CustomDefaulter.
+func (d *LoadPatternCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	loadPattern, ok := obj.(*windtunnelv1alpha1.LoadPattern)
+	if !ok {
+		return fmt.Errorf("expected a LoadPattern object but got %T", obj)
+	}
+
+	if loadPattern.Spec.Executor == "" {
+		loadPattern.Spec.Executor = windtunnelv1alpha1.LoadPatternExecutorRampingArrivalRate
+	}
+	if loadPattern.Spec.TimeUnit == "" {
+		loadPattern.Spec.TimeUnit = loadPatternDefaultTimeUnit
+	}
+	if loadPattern.Spec.MaxDuration == "" && (loadPattern.Spec.Executor == windtunnelv1alpha1.LoadPatternExecutorPerVUIterations ||
+		loadPattern.Spec.Executor == windtunnelv1alpha1.LoadPatternExecutorSharedIterations) {
+		loadPattern.Spec.MaxDuration = loadPatternDefaultMaxDuration
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-windtunnel-plantd-org-v1alpha1-loadpattern,mutating=false,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=loadpatterns,verbs=create;update,versions=v1alpha1,name=vloadpattern.kb.io,admissionReviewVersions=v1
+
+// LoadPatternCustomValidator validates LoadPattern.
+type LoadPatternCustomValidator struct{}
+
+var _ admission.CustomValidator = &LoadPatternCustomValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *LoadPatternCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	loadPattern, ok := obj.(*windtunnelv1alpha1.LoadPattern)
+	if !ok {
+		return nil, fmt.Errorf("expected a LoadPattern object but got %T", obj)
+	}
+	return v.validate(loadPattern)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *LoadPatternCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldLoadPattern, ok := oldObj.(*windtunnelv1alpha1.LoadPattern)
+	if !ok {
+		return nil, fmt.Errorf("expected a LoadPattern object but got %T", oldObj)
+	}
+	loadPattern, ok := newObj.(*windtunnelv1alpha1.LoadPattern)
+	if !ok {
+		return nil, fmt.Errorf("expected a LoadPattern object but got %T", newObj)
+	}
+	// Skip the validation if the spec is unchanged, so that objects created before the
+	// validation existed can still be updated
+	if equality.Semantic.DeepEqual(oldLoadPattern.Spec, loadPattern.Spec) {
+		return nil, nil
+	}
+	return v.validate(loadPattern)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *LoadPatternCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks that the fields required by the executor are set, the durations in the LoadPattern can be parsed,
+// and the VU settings are consistent.
+func (v *LoadPatternCustomValidator) validate(loadPattern *windtunnelv1alpha1.LoadPattern) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	executor := loadPattern.Spec.Executor
+	if executor == "" {
+		executor = windtunnelv1alpha1.LoadPatternExecutorRampingArrivalRate
+	}
+	switch executor {
+	case windtunnelv1alpha1.LoadPatternExecutorRampingArrivalRate, windtunnelv1alpha1.LoadPatternExecutorRampingVUs:
+		if len(loadPattern.Spec.Stages) == 0 {
+			allErrs = append(allErrs, field.Required(specPath.Child("stages"), fmt.Sprintf("must be set for executor \"%s\"", executor)))
+		}
+	case windtunnelv1alpha1.LoadPatternExecutorConstantVUs:
+		if loadPattern.Spec.Duration == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("duration"), fmt.Sprintf("must be set for executor \"%s\"", executor)))
+		}
+	case windtunnelv1alpha1.LoadPatternExecutorConstantArrivalRate:
+		if loadPattern.Spec.Duration == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("duration"), fmt.Sprintf("must be set for executor \"%s\"", executor)))
+		}
+		if loadPattern.Spec.Rate == 0 {
+			allErrs = append(allErrs, field.Required(specPath.Child("rate"), fmt.Sprintf("must be set for executor \"%s\"", executor)))
+		}
+	}
+
+	for _, durationField := range []struct {
+		name  string
+		value string
+	}{
+		{name: "duration", value: loadPattern.Spec.Duration},
+		{name: "maxDuration", value: loadPattern.Spec.MaxDuration},
+	} {
+		if durationField.value == "" {
+			continue
+		}
+		durationPath := specPath.Child(durationField.name)
+		duration, err := time.ParseDuration(durationField.value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(durationPath, durationField.value, err.Error()))
+		} else if duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(durationPath, durationField.value, "must be positive"))
+		}
+	}
+
+	for i, stage := range loadPattern.Spec.Stages {
+		durationPath := specPath.Child("stages").Index(i).Child("duration")
+		duration, err := time.ParseDuration(stage.Duration)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(durationPath, stage.Duration, err.Error()))
+		} else if duration < 0 {
+			allErrs = append(allErrs, field.Invalid(durationPath, stage.Duration, "must not be negative"))
+		}
+	}
+
+	if loadPattern.Spec.TimeUnit != "" {
+		timeUnitPath := specPath.Child("timeUnit")
+		timeUnit, err := time.ParseDuration(loadPattern.Spec.TimeUnit)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(timeUnitPath, loadPattern.Spec.TimeUnit, err.Error()))
+		} else if timeUnit <= 0 {
+			allErrs = append(allErrs, field.Invalid(timeUnitPath, loadPattern.Spec.TimeUnit, "must be positive"))
+		}
+	}
+
+	if loadPattern.Spec.MaxVUs != 0 && loadPattern.Spec.MaxVUs < loadPattern.Spec.PreAllocatedVUs {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxVUs"), loadPattern.Spec.MaxVUs, "must not be less than preAllocatedVUs"))
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(windtunnelv1alpha1.GroupVersion.WithKind("LoadPattern").GroupKind(), loadPattern.Name, allErrs)
+	}
+	return nil, nil
+}
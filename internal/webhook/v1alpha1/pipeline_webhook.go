@@ -0,0 +1,251 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/config"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/utils"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var (
+	pipelineDefaultMetricsPort = config.GetString("monitor.serviceMonitor.endpoint.defaultPort")
+	pipelineDefaultMetricsPath = config.GetString("monitor.serviceMonitor.endpoint.defaultPath")
+)
+
+// SetupPipelineWebhookWithManager registers the webhooks for Pipeline in the manager.
+func SetupPipelineWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&windtunnelv1alpha1.Pipeline{}).
+		WithDefaulter(&PipelineCustomDefaulter{}).
+		WithValidator(&PipelineCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-windtunnel-plantd-org-v1alpha1-pipeline,mutating=true,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=pipelines,verbs=create;update,versions=v1alpha1,name=mpipeline.kb.io,admissionReviewVersions=v1
+
+// PipelineCustomDefaulter sets default values on Pipeline.
+type PipelineCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &PipelineCustomDefaulter{}
+
+// Default implements admission.CustomDefaulter.
+func (d *PipelineCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	pipeline, ok := obj.(*windtunnelv1alpha1.Pipeline)
+	if !ok {
+		return fmt.Errorf("expected a Pipeline object but got %T", obj)
+	}
+
+	// Port and path of the metrics endpoint are only effective for in-cluster Pipeline
+	if pipeline.Spec.InCluster && pipeline.Spec.MetricsEndpoint != nil {
+		if pipeline.Spec.MetricsEndpoint.Port == "" {
+			pipeline.Spec.MetricsEndpoint.Port = pipelineDefaultMetricsPort
+		}
+		if pipeline.Spec.MetricsEndpoint.Path == "" {
+			pipeline.Spec.MetricsEndpoint.Path = pipelineDefaultMetricsPath
+		}
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-windtunnel-plantd-org-v1alpha1-pipeline,mutating=false,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=pipelines,verbs=create;update,versions=v1alpha1,name=vpipeline.kb.io,admissionReviewVersions=v1
+
+// PipelineCustomValidator validates Pipeline.
+type PipelineCustomValidator struct{}
+
+var _ admission.CustomValidator = &PipelineCustomValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *PipelineCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pipeline, ok := obj.(*windtunnelv1alpha1.Pipeline)
+	if !ok {
+		return nil, fmt.Errorf("expected a Pipeline object but got %T", obj)
+	}
+	return v.validate(pipeline)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *PipelineCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldPipeline, ok := oldObj.(*windtunnelv1alpha1.Pipeline)
+	if !ok {
+		return nil, fmt.Errorf("expected a Pipeline object but got %T", oldObj)
+	}
+	pipeline, ok := newObj.(*windtunnelv1alpha1.Pipeline)
+	if !ok {
+		return nil, fmt.Errorf("expected a Pipeline object but got %T", newObj)
+	}
+	// Skip the validation if the spec is unchanged, so that objects created before the
+	// validation existed can still be updated
+	if equality.Semantic.DeepEqual(oldPipeline.Spec, pipeline.Spec) {
+		return nil, nil
+	}
+	return v.validate(pipeline)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *PipelineCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks the endpoints, the metrics endpoint, and the health check URLs of the Pipeline.
+func (v *PipelineCustomValidator) validate(pipeline *windtunnelv1alpha1.Pipeline) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	endpointNames := make(map[string]bool, len(pipeline.Spec.PipelineEndpoints))
+	for i, pipelineEndpoint := range pipeline.Spec.PipelineEndpoints {
+		endpointPath := specPath.Child("pipelineEndpoints").Index(i)
+
+		if pipelineEndpoint.Name == "" {
+			allErrs = append(allErrs, field.Required(endpointPath.Child("name"), ""))
+		} else if endpointNames[pipelineEndpoint.Name] {
+			allErrs = append(allErrs, field.Duplicate(endpointPath.Child("name"), pipelineEndpoint.Name))
+		}
+		endpointNames[pipelineEndpoint.Name] = true
+
+		allErrs = append(allErrs, validatePipelineEndpointProtocol(endpointPath, &pipelineEndpoint)...)
+		if pipelineEndpoint.HTTP != nil {
+			allErrs = append(allErrs, validateHTTPCredentials(endpointPath.Child("http"), pipelineEndpoint.HTTP)...)
+			for name := range pipelineEndpoint.HTTP.Headers {
+				if canonicalName := http.CanonicalHeaderKey(name); canonicalName == "Authorization" || canonicalName == "Proxy-Authorization" {
+					warnings = append(warnings, fmt.Sprintf("Header \"%s\" of endpoint \"%s\" is stored in plain text, consider using secretHeaders or auth",
+						name, pipelineEndpoint.Name,
+					))
+				}
+			}
+		}
+	}
+
+	if metricsEndpoint := pipeline.Spec.MetricsEndpoint; metricsEndpoint != nil {
+		metricsEndpointPath := specPath.Child("metricsEndpoint")
+		if pipeline.Spec.InCluster {
+			if metricsEndpoint.ServiceRef == nil || metricsEndpoint.ServiceRef.Name == "" {
+				allErrs = append(allErrs, field.Required(metricsEndpointPath.Child("serviceRef", "name"), "must be set for in-cluster Pipeline"))
+			}
+		} else {
+			if metricsEndpoint.HTTP == nil || metricsEndpoint.HTTP.URL == "" {
+				allErrs = append(allErrs, field.Required(metricsEndpointPath.Child("http", "url"), "must be set for out-cluster Pipeline"))
+			} else if _, err := utils.GetURLPort(metricsEndpoint.HTTP.URL); err != nil {
+				allErrs = append(allErrs, field.Invalid(metricsEndpointPath.Child("http", "url"), metricsEndpoint.HTTP.URL, err.Error()))
+			}
+		}
+	}
+
+	for i, healthCheckURL := range pipeline.Spec.HealthCheckURLs {
+		if _, err := url.ParseRequestURI(healthCheckURL); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("healthCheckURLs").Index(i), healthCheckURL, err.Error()))
+		}
+	}
+
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(windtunnelv1alpha1.GroupVersion.WithKind("Pipeline").GroupKind(), pipeline.Name, allErrs)
+	}
+	return warnings, nil
+}
+
+// validatePipelineEndpointProtocol checks that exactly one protocol is configured in the PipelineEndpoint,
+// and that the configurations of the protocol are complete.
+func validatePipelineEndpointProtocol(fldPath *field.Path, pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint) field.ErrorList {
+	var allErrs field.ErrorList
+	numProtocols := 0
+
+	if pipelineEndpoint.HTTP != nil {
+		numProtocols++
+		if pipelineEndpoint.HTTP.URL == "" || pipelineEndpoint.HTTP.Method == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("http"), "must set url and method"))
+		} else if _, err := url.ParseRequestURI(pipelineEndpoint.HTTP.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("http", "url"), pipelineEndpoint.HTTP.URL, err.Error()))
+		}
+	}
+	if pipelineEndpoint.GRPC != nil {
+		numProtocols++
+		if pipelineEndpoint.GRPC.Address == "" || pipelineEndpoint.GRPC.Method == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("grpc"), "must set address and method"))
+		}
+	}
+	if pipelineEndpoint.Kafka != nil {
+		numProtocols++
+		if len(pipelineEndpoint.Kafka.Brokers) == 0 || pipelineEndpoint.Kafka.Topic == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("kafka"), "must set brokers and topic"))
+		}
+	}
+	if pipelineEndpoint.MQTT != nil {
+		numProtocols++
+		if pipelineEndpoint.MQTT.URL == "" || pipelineEndpoint.MQTT.Topic == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("mqtt"), "must set url and topic"))
+		} else if _, err := url.ParseRequestURI(pipelineEndpoint.MQTT.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mqtt", "url"), pipelineEndpoint.MQTT.URL, err.Error()))
+		}
+	}
+	if pipelineEndpoint.WebSocket != nil {
+		numProtocols++
+		if pipelineEndpoint.WebSocket.URL == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("websocket", "url"), ""))
+		} else if _, err := url.ParseRequestURI(pipelineEndpoint.WebSocket.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("websocket", "url"), pipelineEndpoint.WebSocket.URL, err.Error()))
+		}
+	}
+
+	if numProtocols == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "must set one of http, grpc, kafka, mqtt, and websocket"))
+	} else if numProtocols > 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, pipelineEndpoint.Name, "must set only one of http, grpc, kafka, mqtt, and websocket"))
+	}
+	return allErrs
+}
+
+// validateHTTPCredentials checks the Secret references in the secret headers and the authentication of the HTTP
+// configurations, and that at most one authentication sets the `Authorization` header.
+func validateHTTPCredentials(fldPath *field.Path, httpSpec *windtunnelv1alpha1.HTTP) field.ErrorList {
+	var allErrs field.ErrorList
+
+	headerNames := make(map[string]bool, len(httpSpec.SecretHeaders))
+	for i, secretHeader := range httpSpec.SecretHeaders {
+		secretHeaderPath := fldPath.Child("secretHeaders").Index(i)
+		canonicalName := http.CanonicalHeaderKey(secretHeader.Name)
+		if headerNames[canonicalName] {
+			allErrs = append(allErrs, field.Duplicate(secretHeaderPath.Child("name"), secretHeader.Name))
+		}
+		headerNames[canonicalName] = true
+		allErrs = append(allErrs, validateSecretKeySelector(secretHeaderPath.Child("secretKeyRef"), &secretHeader.SecretKeyRef)...)
+	}
+
+	auth := httpSpec.Auth
+	if auth == nil {
+		return allErrs
+	}
+	authPath := fldPath.Child("auth")
+	if auth.Basic != nil && auth.OAuth2 != nil {
+		allErrs = append(allErrs, field.Invalid(authPath, "basic, oauth2", "must set only one of basic and oauth2"))
+	}
+	if auth.Basic != nil {
+		allErrs = append(allErrs, validateSecretKeySelector(authPath.Child("basic", "username"), &auth.Basic.Username)...)
+		allErrs = append(allErrs, validateSecretKeySelector(authPath.Child("basic", "password"), &auth.Basic.Password)...)
+	}
+	if auth.OAuth2 != nil {
+		if _, err := url.ParseRequestURI(auth.OAuth2.TokenURL); err != nil {
+			allErrs = append(allErrs, field.Invalid(authPath.Child("oauth2", "tokenURL"), auth.OAuth2.TokenURL, err.Error()))
+		}
+		allErrs = append(allErrs, validateSecretKeySelector(authPath.Child("oauth2", "clientID"), &auth.OAuth2.ClientID)...)
+		allErrs = append(allErrs, validateSecretKeySelector(authPath.Child("oauth2", "clientSecret"), &auth.OAuth2.ClientSecret)...)
+	}
+	if auth.HMAC != nil {
+		allErrs = append(allErrs, validateSecretKeySelector(authPath.Child("hmac", "key"), &auth.HMAC.Key)...)
+	}
+	if auth.ClientCertificateSecretRef != nil && auth.ClientCertificateSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(authPath.Child("clientCertificateSecretRef", "name"), "must reference a Secret"))
+	}
+	return allErrs
+}
@@ -0,0 +1,184 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/datagen"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupSchemaWebhookWithManager registers the webhooks for Schema in the manager.
+func SetupSchemaWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&windtunnelv1alpha1.Schema{}).
+		WithDefaulter(&SchemaCustomDefaulter{}).
+		WithValidator(&SchemaCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-windtunnel-plantd-org-v1alpha1-schema,mutating=true,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=schemas,verbs=create;update,versions=v1alpha1,name=mschema.kb.io,admissionReviewVersions=v1
+
+// SchemaCustomDefaulter sets default values on Schema.
+type SchemaCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &SchemaCustomDefaulter{}
+
+// Default implements admission.CustomDefaulter.
+func (d *SchemaCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	if _, ok := obj.(*windtunnelv1alpha1.Schema); !ok {
+		return fmt.Errorf("expected a Schema object but got %T", obj)
+	}
+
+	// Schema has no optional fields to default for now, the handler is kept so that
+	// defaults can be added later without changing the webhook configurations
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-windtunnel-plantd-org-v1alpha1-schema,mutating=false,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=schemas,verbs=create;update,versions=v1alpha1,name=vschema.kb.io,admissionReviewVersions=v1
+
+// SchemaCustomValidator validates Schema.
+type SchemaCustomValidator struct{}
+
+var _ admission.CustomValidator = &SchemaCustomValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *SchemaCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	schema, ok := obj.(*windtunnelv1alpha1.Schema)
+	if !ok {
+		return nil, fmt.Errorf("expected a Schema object but got %T", obj)
+	}
+	return v.validate(schema)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *SchemaCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldSchema, ok := oldObj.(*windtunnelv1alpha1.Schema)
+	if !ok {
+		return nil, fmt.Errorf("expected a Schema object but got %T", oldObj)
+	}
+	schema, ok := newObj.(*windtunnelv1alpha1.Schema)
+	if !ok {
+		return nil, fmt.Errorf("expected a Schema object but got %T", newObj)
+	}
+	// Skip the validation if the spec is unchanged, so that objects created before the
+	// validation existed can still be updated
+	if equality.Semantic.DeepEqual(oldSchema.Spec, schema.Spec) {
+		return nil, nil
+	}
+	return v.validate(schema)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *SchemaCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks that every column of the Schema resolves to a gofakeit function, a formula, or a reference,
+// using the same lookups as the data generator. References to other Schemas are checked by the DataSet.
+func (v *SchemaCustomValidator) validate(schema *windtunnelv1alpha1.Schema) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	columnsPath := field.NewPath("spec", "columns")
+
+	columnNames := make(map[string]bool, len(schema.Spec.Columns))
+	for i, column := range schema.Spec.Columns {
+		columnPath := columnsPath.Index(i)
+
+		if column.Name == "" {
+			allErrs = append(allErrs, field.Required(columnPath.Child("name"), ""))
+		} else if columnNames[column.Name] {
+			allErrs = append(allErrs, field.Duplicate(columnPath.Child("name"), column.Name))
+		}
+		columnNames[column.Name] = true
+
+		if column.Reference != nil {
+			referencePath := columnPath.Child("reference")
+			if column.Type != "" || column.Formula.Name != "" {
+				allErrs = append(allErrs, field.Forbidden(referencePath, "cannot be used together with type or formula"))
+			}
+			if column.Reference.Schema == "" {
+				allErrs = append(allErrs, field.Required(referencePath.Child("schema"), ""))
+			}
+			if column.Reference.Column == "" {
+				allErrs = append(allErrs, field.Required(referencePath.Child("column"), ""))
+			} else if column.Reference.Schema == schema.Name && !hasColumn(schema, column.Reference.Column) {
+				allErrs = append(allErrs, field.NotFound(referencePath.Child("column"), column.Reference.Column))
+			}
+			continue
+		}
+
+		if column.Type == "" && column.Formula.Name == "" {
+			allErrs = append(allErrs, field.Required(columnPath, "must set one of type, formula, or reference"))
+			continue
+		}
+
+		if column.Type != "" {
+			info := gofakeit.GetFuncLookup(column.Type)
+			if info == nil {
+				allErrs = append(allErrs, field.Invalid(columnPath.Child("type"), column.Type, "unknown gofakeit function"))
+			} else {
+				acceptedParams := make(map[string]bool, len(info.Params))
+				for _, param := range info.Params {
+					acceptedParams[param.Field] = true
+				}
+				for param := range column.Params {
+					if !acceptedParams[param] {
+						warnings = append(warnings, fmt.Sprintf("%s: parameter \"%s\" is ignored by type \"%s\"",
+							columnPath.Child("params"), param, column.Type,
+						))
+					}
+				}
+			}
+		}
+
+		if column.Formula.Name != "" {
+			if datagen.GetFormulaLookup(column.Formula.Name) == nil {
+				allErrs = append(allErrs, field.Invalid(columnPath.Child("formula", "name"), column.Formula.Name, "unknown formula"))
+			} else if column.Type != "" {
+				warnings = append(warnings, fmt.Sprintf("%s: overridden since formula is set", columnPath.Child("type")))
+			}
+			if column.Formula.Name == datagen.ExpressionFormulaName {
+				allErrs = append(allErrs, validateExpression(columnPath.Child("formula", "args"), schema, column.Formula.Args)...)
+			}
+		}
+	}
+
+	// Columns are generated in the order of their dependencies, which must not form a cycle
+	if _, err := datagen.SortColumnsByDependency(schema); err != nil {
+		allErrs = append(allErrs, field.Invalid(columnsPath, getColumnNames(schema), err.Error()))
+	}
+
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(windtunnelv1alpha1.GroupVersion.WithKind("Schema").GroupKind(), schema.Name, allErrs)
+	}
+	return warnings, nil
+}
+
+// validateExpression checks that the arguments of an expression formula consist of a single expression with valid
+// syntax, using only columns of the Schema. Types are checked when the data is generated, as they may depend on
+// columns in other Schemas.
+func validateExpression(argsPath *field.Path, schema *windtunnelv1alpha1.Schema, args []string) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(args) != 1 {
+		return append(allErrs, field.Invalid(argsPath, args, "must contain exactly one expression"))
+	}
+	expr, err := datagen.ParseExpression(args[0])
+	if err != nil {
+		return append(allErrs, field.Invalid(argsPath.Index(0), args[0], err.Error()))
+	}
+	for _, name := range expr.Columns() {
+		if !hasColumn(schema, name) {
+			allErrs = append(allErrs, field.NotFound(argsPath.Index(0), name))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,140 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupSimulationWebhookWithManager registers the webhooks for Simulation in the manager.
+func SetupSimulationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&windtunnelv1alpha1.Simulation{}).
+		WithDefaulter(&SimulationCustomDefaulter{}).
+		WithValidator(&SimulationCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-windtunnel-plantd-org-v1alpha1-simulation,mutating=true,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=simulations,verbs=create;update,versions=v1alpha1,name=msimulation.kb.io,admissionReviewVersions=v1
+
+// SimulationCustomDefaulter sets default values on Simulation.
+type SimulationCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &SimulationCustomDefaulter{}
+
+// Default implements admission.CustomDefaulter.
+func (d *SimulationCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	simulation, ok := obj.(*windtunnelv1alpha1.Simulation)
+	if !ok {
+		return fmt.Errorf("expected a Simulation object but got %T", obj)
+	}
+
+	// Referenced objects default to the namespace of the Simulation
+	for _, ref := range []*corev1.ObjectReference{
+		simulation.Spec.DigitalTwinRef,
+		simulation.Spec.TrafficModelRef,
+		simulation.Spec.NetCostRef,
+		simulation.Spec.ScenarioRef,
+	} {
+		if ref != nil && ref.Namespace == "" {
+			ref.Namespace = simulation.Namespace
+		}
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-windtunnel-plantd-org-v1alpha1-simulation,mutating=false,failurePolicy=fail,sideEffects=None,groups=windtunnel.plantd.org,resources=simulations,verbs=create;update,versions=v1alpha1,name=vsimulation.kb.io,admissionReviewVersions=v1
+
+// SimulationCustomValidator validates Simulation.
+type SimulationCustomValidator struct {
+	Client client.Client
+}
+
+var _ admission.CustomValidator = &SimulationCustomValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *SimulationCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	simulation, ok := obj.(*windtunnelv1alpha1.Simulation)
+	if !ok {
+		return nil, fmt.Errorf("expected a Simulation object but got %T", obj)
+	}
+	return v.validate(ctx, simulation)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *SimulationCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldSimulation, ok := oldObj.(*windtunnelv1alpha1.Simulation)
+	if !ok {
+		return nil, fmt.Errorf("expected a Simulation object but got %T", oldObj)
+	}
+	simulation, ok := newObj.(*windtunnelv1alpha1.Simulation)
+	if !ok {
+		return nil, fmt.Errorf("expected a Simulation object but got %T", newObj)
+	}
+	// Skip the validation if the spec is unchanged, so that changes in the referenced objects
+	// never block the reconciliation
+	if equality.Semantic.DeepEqual(oldSimulation.Spec, simulation.Spec) {
+		return nil, nil
+	}
+	return v.validate(ctx, simulation)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *SimulationCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks that the references required by the Simulation are set. The DigitalTwin is fetched to
+// determine whether a Scenario is required. A DigitalTwin that does not exist yet only produces a warning,
+// as it may be created later.
+func (v *SimulationCustomValidator) validate(ctx context.Context, simulation *windtunnelv1alpha1.Simulation) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if simulation.Spec.TrafficModelRef == nil || simulation.Spec.TrafficModelRef.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("trafficModelRef", "name"), "must reference a TrafficModel"))
+	}
+
+	// Scenario is required unless the DigitalTwin is of type `regular`
+	requireScenario := true
+	if simulation.Spec.DigitalTwinRef != nil {
+		if simulation.Spec.DigitalTwinRef.Name == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("digitalTwinRef", "name"), ""))
+		} else {
+			digitalTwin := &windtunnelv1alpha1.DigitalTwin{}
+			digitalTwinName := types.NamespacedName{
+				Namespace: simulation.Spec.DigitalTwinRef.Namespace,
+				Name:      simulation.Spec.DigitalTwinRef.Name,
+			}
+			if err := v.Client.Get(ctx, digitalTwinName, digitalTwin); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				warnings = append(warnings, fmt.Sprintf("DigitalTwin \"%s\" does not exist yet", digitalTwinName))
+			} else if digitalTwin.Spec.DigitalTwinType == "regular" {
+				requireScenario = false
+			}
+		}
+	}
+	if requireScenario && (simulation.Spec.ScenarioRef == nil || simulation.Spec.ScenarioRef.Name == "") {
+		allErrs = append(allErrs, field.Required(specPath.Child("scenarioRef", "name"), "must reference a Scenario unless the DigitalTwin is of type regular"))
+	}
+
+	if len(allErrs) != 0 {
+		return warnings, apierrors.NewInvalid(windtunnelv1alpha1.GroupVersion.WithKind("Simulation").GroupKind(), simulation.Name, allErrs)
+	}
+	return warnings, nil
+}
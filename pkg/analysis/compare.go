@@ -0,0 +1,262 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Default tolerances of the comparison with the baseline Experiment.
+const (
+	defaultLatencyTolerance      = 0.1
+	defaultAchievedRateTolerance = 0.05
+	defaultErrorRateTolerance    = 0.01
+	defaultSignificanceLevel     = 0.05
+)
+
+// rateSampleInterval is the interval to sample the rate of requests over the load generation for the statistical test.
+const rateSampleInterval = 30 * time.Second
+
+// tolerances contains the parsed tolerances of the comparison with the baseline Experiment.
+type tolerances struct {
+	latency           float64
+	achievedRate      float64
+	errorRate         float64
+	significanceLevel float64
+}
+
+// getTolerances parses the tolerances of the comparison with the baseline Experiment, using the default values
+// for the unset fields.
+func getTolerances(regressionTolerance *windtunnelv1alpha1.RegressionTolerance) tolerances {
+	t := tolerances{
+		latency:           defaultLatencyTolerance,
+		achievedRate:      defaultAchievedRateTolerance,
+		errorRate:         defaultErrorRateTolerance,
+		significanceLevel: defaultSignificanceLevel,
+	}
+	if regressionTolerance == nil {
+		return t
+	}
+
+	fields := []struct {
+		value string
+		field *float64
+	}{
+		{regressionTolerance.Latency, &t.latency},
+		{regressionTolerance.AchievedRate, &t.achievedRate},
+		{regressionTolerance.ErrorRate, &t.errorRate},
+		{regressionTolerance.SignificanceLevel, &t.significanceLevel},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if value, err := strconv.ParseFloat(f.value, 64); err == nil {
+			*f.field = value
+		}
+	}
+	return t
+}
+
+// Compare compares the results of a completed Experiment with those of its completed baseline Experiment,
+// and returns the verdict and the result of each metric.
+// The latency and the achieved rate are taken from the summaries of the Experiments, and the error rate is queried
+// over the load generation. A decrease of the achieved rate is also tested by the Welch's t-test on the rate sampled
+// over the load generation. Only the endpoints summarized in both Experiments are compared.
+// A metric whose value cannot be observed does not regress.
+func (q *Querier) Compare(ctx context.Context, experiment, baseline *windtunnelv1alpha1.Experiment, regressionTolerance *windtunnelv1alpha1.RegressionTolerance) (windtunnelv1alpha1.ComparisonVerdict, []windtunnelv1alpha1.ComparisonResult) {
+	t := getTolerances(regressionTolerance)
+
+	baselineSummaries := make(map[string]*windtunnelv1alpha1.EndpointSummary, len(baseline.Status.Summaries))
+	for i := range baseline.Status.Summaries {
+		baselineSummaries[baseline.Status.Summaries[i].EndpointName] = &baseline.Status.Summaries[i]
+	}
+
+	var results []windtunnelv1alpha1.ComparisonResult
+	for i := range experiment.Status.Summaries {
+		summary := &experiment.Status.Summaries[i]
+		baselineSummary, ok := baselineSummaries[summary.EndpointName]
+		if !ok {
+			continue
+		}
+
+		latencies := []struct {
+			name          string
+			value         *metav1.Duration
+			baselineValue *metav1.Duration
+		}{
+			{"latencyP50", summary.LatencyP50, baselineSummary.LatencyP50},
+			{"latencyP95", summary.LatencyP95, baselineSummary.LatencyP95},
+			{"latencyP99", summary.LatencyP99, baselineSummary.LatencyP99},
+		}
+		for _, latency := range latencies {
+			value := durationSeconds(latency.value)
+			baselineValue := durationSeconds(latency.baselineValue)
+			result := newComparisonResult(latency.name, summary.EndpointName, value, baselineValue, t.latency)
+			if result.Message == "" {
+				// A higher latency is worse
+				result.Change, result.Regressed, result.Message = compareRelative(*value-*baselineValue, *baselineValue, t.latency)
+			}
+			results = append(results, result)
+		}
+
+		results = append(results, q.compareAchievedRate(ctx, experiment, baseline, summary, baselineSummary, t))
+		results = append(results, q.compareErrorRate(ctx, experiment, baseline, summary.EndpointName, t))
+	}
+
+	verdict := windtunnelv1alpha1.ComparisonNoRegression
+	for _, result := range results {
+		if result.Regressed {
+			verdict = windtunnelv1alpha1.ComparisonRegressed
+			break
+		}
+	}
+	return verdict, results
+}
+
+// compareAchievedRate compares the achieved rate of an endpoint with its baseline value. A decrease beyond the
+// tolerance is a regression only if it is significant by the one-sided Welch's t-test, or if the test cannot be
+// performed.
+func (q *Querier) compareAchievedRate(ctx context.Context, experiment, baseline *windtunnelv1alpha1.Experiment, summary, baselineSummary *windtunnelv1alpha1.EndpointSummary, t tolerances) windtunnelv1alpha1.ComparisonResult {
+	value := parseOptionalFloat(summary.AchievedRate)
+	baselineValue := parseOptionalFloat(baselineSummary.AchievedRate)
+	result := newComparisonResult("achievedRate", summary.EndpointName, value, baselineValue, t.achievedRate)
+	if result.Message != "" {
+		return result
+	}
+	// A lower achieved rate is worse
+	result.Change, result.Regressed, result.Message = compareRelative(*baselineValue-*value, *baselineValue, t.achievedRate)
+
+	samples, err := q.queryRateSamples(ctx, experiment, summary.EndpointName)
+	if err != nil {
+		return result
+	}
+	baselineSamples, err := q.queryRateSamples(ctx, baseline, summary.EndpointName)
+	if err != nil {
+		return result
+	}
+	// The test is one-sided, as only a decrease is a regression
+	if pValue, ok := welchTTestLess(samples, baselineSamples); ok {
+		result.PValue = formatFloat(pValue)
+		result.Regressed = result.Regressed && pValue < t.significanceLevel
+	}
+	return result
+}
+
+// compareErrorRate compares the error rate of an endpoint over the load generation with its baseline value.
+func (q *Querier) compareErrorRate(ctx context.Context, experiment, baseline *windtunnelv1alpha1.Experiment, endpointName string, t tolerances) windtunnelv1alpha1.ComparisonResult {
+	value, err := q.queryLoadErrorRate(ctx, experiment, endpointName)
+	if err != nil {
+		return newComparisonErrorResult("errorRate", endpointName, err, t.errorRate)
+	}
+	baselineValue, err := q.queryLoadErrorRate(ctx, baseline, endpointName)
+	if err != nil {
+		return newComparisonErrorResult("errorRate", endpointName, err, t.errorRate)
+	}
+
+	result := newComparisonResult("errorRate", endpointName, &value, &baselineValue, t.errorRate)
+	change := value - baselineValue
+	result.Change = formatFloat(change)
+	result.Regressed = change > t.errorRate
+	return result
+}
+
+// queryRateSamples queries the rate of requests to an endpoint sampled over the load generation of an Experiment.
+func (q *Querier) queryRateSamples(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, endpointName string) ([]float64, error) {
+	start := experiment.Status.StartTime.Add(rateSampleInterval)
+	end := getLoadEndTime(experiment)
+	if !start.Before(end) {
+		return nil, ErrNoData
+	}
+	return q.QuerySamples(ctx, getRateQuery(experiment, endpointName, rateSampleInterval), start, end, rateSampleInterval)
+}
+
+// queryLoadErrorRate queries the error rate of an endpoint over the load generation of an Experiment.
+func (q *Querier) queryLoadErrorRate(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, endpointName string) (float64, error) {
+	end := getLoadEndTime(experiment)
+	return q.QueryValue(ctx, getErrorRateQuery(experiment, endpointName, end.Sub(experiment.Status.StartTime.Time)), end)
+}
+
+// getLoadEndTime returns the time when the load generation of a completed Experiment ended.
+func getLoadEndTime(experiment *windtunnelv1alpha1.Experiment) time.Time {
+	if experiment.Status.DrainingStartTime != nil {
+		return experiment.Status.DrainingStartTime.Time
+	}
+	return experiment.Status.CompletionTime.Time
+}
+
+// newComparisonResult creates the result of comparing a metric with its baseline value, without the change.
+// The message is set if either value cannot be observed.
+func newComparisonResult(name, endpointName string, value, baselineValue *float64, tolerance float64) windtunnelv1alpha1.ComparisonResult {
+	result := windtunnelv1alpha1.ComparisonResult{
+		Name:         name,
+		EndpointName: endpointName,
+		Tolerance:    formatFloat(tolerance),
+	}
+	if value != nil {
+		result.Value = formatFloat(*value)
+	}
+	if baselineValue != nil {
+		result.BaselineValue = formatFloat(*baselineValue)
+	}
+	if value == nil || baselineValue == nil {
+		result.Message = "No data"
+	}
+	return result
+}
+
+// newComparisonErrorResult creates the result of a metric that cannot be compared because of the error.
+func newComparisonErrorResult(name, endpointName string, err error, tolerance float64) windtunnelv1alpha1.ComparisonResult {
+	result := windtunnelv1alpha1.ComparisonResult{
+		Name:         name,
+		EndpointName: endpointName,
+		Tolerance:    formatFloat(tolerance),
+	}
+	if errors.Is(err, ErrNoData) {
+		result.Message = "No data"
+	} else {
+		result.Message = fmt.Sprintf("Cannot query the value: %s", err)
+	}
+	return result
+}
+
+// compareRelative returns the change relative to the baseline value given the absolute change, which is positive if
+// the value is worse, and whether it exceeds the tolerance. A message is returned instead if the baseline value is 0
+// but the value is not.
+func compareRelative(change, baselineValue, tolerance float64) (string, bool, string) {
+	if baselineValue == 0 {
+		if change == 0 {
+			return formatFloat(0), false, ""
+		}
+		return "", false, "Cannot calculate the change relative to 0"
+	}
+	relativeChange := change / baselineValue
+	return formatFloat(relativeChange), relativeChange > tolerance, ""
+}
+
+// durationSeconds returns the duration in seconds, or nil if the duration is not set.
+func durationSeconds(duration *metav1.Duration) *float64 {
+	if duration == nil {
+		return nil
+	}
+	seconds := duration.Seconds()
+	return &seconds
+}
+
+// parseOptionalFloat parses a float number in string format, or returns nil if it is not set or invalid.
+func parseOptionalFloat(s string) *float64 {
+	if s == "" {
+		return nil
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
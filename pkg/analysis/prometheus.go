@@ -0,0 +1,224 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/config"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/utils"
+
+	"github.com/prometheus/client_golang/api"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+var (
+	promURL = fmt.Sprintf("http://%s:%d",
+		utils.GetServiceARecord(config.GetString("core.thanos.querier.name"), config.GetString("core.namespace")),
+		config.GetInt32("core.thanos.querier.serviceHttpPort"),
+	)
+)
+
+// ErrNoData is returned when a query returns no sample.
+var ErrNoData = errors.New("no data")
+
+// Endpoint contains the information of an endpoint in an Experiment needed to analyze its results.
+type Endpoint struct {
+	// Name of the endpoint.
+	Name string
+	// Protocol of the endpoint.
+	Protocol windtunnelv1alpha1.EndpointProtocol
+	// Target rate of requests per second of the LoadPattern, or 0 if the executor has no target rate.
+	TargetRate float64
+}
+
+// Querier runs PromQL queries against the Thanos querier.
+type Querier struct {
+	PromAPI prometheusv1.API
+}
+
+// NewQuerier creates a new Querier.
+func NewQuerier() (*Querier, error) {
+	promClient, err := api.NewClient(api.Config{
+		Address: promURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Querier{
+		PromAPI: prometheusv1.NewAPI(promClient),
+	}, nil
+}
+
+// QueryValue runs an instant query at the given time and returns the value of its only sample.
+// It returns ErrNoData if the query returns no sample or NaN.
+func (q *Querier) QueryValue(ctx context.Context, query string, ts time.Time) (float64, error) {
+	result, _, err := q.PromAPI.Query(ctx, query, ts)
+	if err != nil {
+		return 0, err
+	}
+
+	var value float64
+	switch v := result.(type) {
+	case model.Vector:
+		if len(v) == 0 {
+			return 0, ErrNoData
+		}
+		if len(v) > 1 {
+			return 0, fmt.Errorf("expect 1 sample but got %d", len(v))
+		}
+		value = float64(v[0].Value)
+	case *model.Scalar:
+		value = float64(v.Value)
+	default:
+		return 0, fmt.Errorf("cannot convert data of type \"%s\" to a value", result.Type())
+	}
+	if math.IsNaN(value) {
+		return 0, ErrNoData
+	}
+	return value, nil
+}
+
+// QueryVector runs an instant query at the given time and returns the values of its samples by the given label.
+func (q *Querier) QueryVector(ctx context.Context, query string, ts time.Time, label string) (map[string]float64, error) {
+	result, _, err := q.PromAPI.Query(ctx, query, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	vectorVal, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert data of type \"%s\" to a vector", result.Type())
+	}
+	values := make(map[string]float64, len(vectorVal))
+	for _, sampleVal := range vectorVal {
+		if math.IsNaN(float64(sampleVal.Value)) {
+			continue
+		}
+		values[string(sampleVal.Metric[model.LabelName(label)])] = float64(sampleVal.Value)
+	}
+	return values, nil
+}
+
+// QuerySamples runs a range query between the given times and returns the values of its only series.
+// It returns ErrNoData if the query returns no series, and skips the NaN values.
+func (q *Querier) QuerySamples(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]float64, error) {
+	result, _, err := q.PromAPI.QueryRange(ctx, query, prometheusv1.Range{
+		Start: start,
+		End:   end,
+		Step:  step,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matrixVal, ok := result.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert data of type \"%s\" to a matrix", result.Type())
+	}
+	if len(matrixVal) == 0 {
+		return nil, ErrNoData
+	}
+	if len(matrixVal) > 1 {
+		return nil, fmt.Errorf("expect 1 series but got %d", len(matrixVal))
+	}
+	values := make([]float64, 0, len(matrixVal[0].Values))
+	for _, sampleVal := range matrixVal[0].Values {
+		if math.IsNaN(float64(sampleVal.Value)) {
+			continue
+		}
+		values = append(values, float64(sampleVal.Value))
+	}
+	return values, nil
+}
+
+// getRange returns the PromQL range covering the given duration, rounded up to seconds.
+func getRange(duration time.Duration) string {
+	return fmt.Sprintf("%ds", int64(math.Ceil(duration.Seconds())))
+}
+
+// getK6Selector returns the label selector of the K6 metrics of an endpoint in an Experiment,
+// using the tags set on the TestRun.
+func getK6Selector(experiment *windtunnelv1alpha1.Experiment, endpointName string) string {
+	return fmt.Sprintf("{experiment=\"%s/%s\",endpoint=\"%s\"}", experiment.Namespace, experiment.Name, endpointName)
+}
+
+// k6AuthGroup is the K6 group of the requests made by the load generator scripts to authenticate, e.g., to fetch
+// the OAuth 2.0 token. They are excluded from the request metrics of the endpoint.
+const k6AuthGroup = "::oauth2"
+
+// getK6RequestSelector returns the label selector of the K6 request metrics of an endpoint in an Experiment,
+// excluding the requests to authenticate. Extra matchers are appended to the selector, e.g., `,status="500"`.
+func getK6RequestSelector(experiment *windtunnelv1alpha1.Experiment, endpointName string, extraMatchers string) string {
+	return fmt.Sprintf("{experiment=\"%s/%s\",endpoint=\"%s\",group!=\"%s\"%s}",
+		experiment.Namespace, experiment.Name, endpointName, k6AuthGroup, extraMatchers,
+	)
+}
+
+// getK6LatencyMetric returns the name of the K6 trend metric measuring the latency of the requests
+// for the given protocol, without the suffix of the trend stat.
+func getK6LatencyMetric(protocol windtunnelv1alpha1.EndpointProtocol) string {
+	switch protocol {
+	case windtunnelv1alpha1.EndpointProtocolHTTP:
+		return "k6_http_req_duration"
+	case windtunnelv1alpha1.EndpointProtocolGRPC:
+		return "k6_grpc_req_duration"
+	default:
+		return "k6_iteration_duration"
+	}
+}
+
+// The K6 remote write output keeps the counters, rates, and trend stats cumulative over the whole test, so the last
+// value of each series is the result of the test. Each K6 runner writes its own series.
+
+// getLatencyQuery returns the query of a percentile of the latency of the requests to an endpoint, in seconds.
+// The trend stat must be enabled in the K6 remote write output. The highest value among the K6 runners is used.
+func getLatencyQuery(experiment *windtunnelv1alpha1.Experiment, endpointName string, protocol windtunnelv1alpha1.EndpointProtocol, stat string, duration time.Duration) string {
+	return fmt.Sprintf("max(last_over_time(%s_%s%s[%s]))",
+		getK6LatencyMetric(protocol), stat, getK6RequestSelector(experiment, endpointName, ""), getRange(duration),
+	)
+}
+
+// getErrorRateQuery returns the query of the rate of failed checks of an endpoint.
+// The highest value among the K6 runners is used.
+func getErrorRateQuery(experiment *windtunnelv1alpha1.Experiment, endpointName string, duration time.Duration) string {
+	return fmt.Sprintf("1 - min(last_over_time(k6_checks_rate%s[%s]))",
+		getK6Selector(experiment, endpointName), getRange(duration),
+	)
+}
+
+// getCounterQuery returns the query of the total of a K6 counter of an endpoint over all K6 runners.
+func getCounterQuery(experiment *windtunnelv1alpha1.Experiment, endpointName string, metric string, duration time.Duration) string {
+	return fmt.Sprintf("sum(max_over_time(%s%s[%s]))",
+		metric, getK6Selector(experiment, endpointName), getRange(duration),
+	)
+}
+
+// getRateQuery returns the query of the rate of requests to an endpoint over all K6 runners,
+// averaged over the given window.
+func getRateQuery(experiment *windtunnelv1alpha1.Experiment, endpointName string, window time.Duration) string {
+	return fmt.Sprintf("sum(rate(k6_iterations_total%s[%s]))",
+		getK6Selector(experiment, endpointName), getRange(window),
+	)
+}
+
+// getHTTPErrorsQuery returns the query of the number of HTTP requests with unexpected responses of an endpoint
+// by the status code.
+func getHTTPErrorsQuery(experiment *windtunnelv1alpha1.Experiment, endpointName string, duration time.Duration) string {
+	return fmt.Sprintf("sum by (status) (max_over_time(k6_http_reqs_total%s[%s]))",
+		getK6RequestSelector(experiment, endpointName, ",expected_response=\"false\""), getRange(duration),
+	)
+}
+
+// getRateDuration returns the duration to calculate the achieved rate of an endpoint over. The duration calculated
+// from the LoadPattern is preferred, which excludes the time to start the K6 runners.
+func getRateDuration(experiment *windtunnelv1alpha1.Experiment, endpointName string, loadDuration time.Duration) time.Duration {
+	if duration := experiment.Status.Durations[endpointName]; duration != nil && duration.Duration > 0 {
+		return duration.Duration
+	}
+	return loadDuration
+}
@@ -0,0 +1,126 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+)
+
+// EvaluateSLO evaluates the SLO of a completed Experiment and returns the verdict and the result of each criterion.
+// The K6 metrics are evaluated over the load generation, and the assertions over the whole Experiment.
+// A criterion whose value cannot be observed is not met.
+func (q *Querier) EvaluateSLO(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, endpoints []Endpoint) (windtunnelv1alpha1.SLOVerdict, []windtunnelv1alpha1.SLOResult) {
+	slo := experiment.Spec.SLO
+	loadEndTime := experiment.Status.DrainingStartTime.Time
+	loadDuration := loadEndTime.Sub(experiment.Status.StartTime.Time)
+
+	var results []windtunnelv1alpha1.SLOResult
+	for _, endpoint := range endpoints {
+		if slo.LatencyP95 != nil {
+			query := getLatencyQuery(experiment, endpoint.Name, endpoint.Protocol, "p95", loadDuration)
+			value, err := q.QueryValue(ctx, query, loadEndTime)
+			results = append(results, newSLOResult("latencyP95", endpoint.Name, value, err,
+				windtunnelv1alpha1.SLOOperatorLessThanOrEqual, slo.LatencyP95.Seconds(),
+			))
+		}
+
+		if slo.ErrorRate != "" {
+			threshold, _ := strconv.ParseFloat(slo.ErrorRate, 64)
+			query := getErrorRateQuery(experiment, endpoint.Name, loadDuration)
+			value, err := q.QueryValue(ctx, query, loadEndTime)
+			results = append(results, newSLOResult("errorRate", endpoint.Name, value, err,
+				windtunnelv1alpha1.SLOOperatorLessThanOrEqual, threshold,
+			))
+		}
+
+		if slo.MinRateRatio != "" {
+			threshold, _ := strconv.ParseFloat(slo.MinRateRatio, 64)
+			if endpoint.TargetRate > 0 {
+				rateDuration := getRateDuration(experiment, endpoint.Name, loadDuration)
+				query := getCounterQuery(experiment, endpoint.Name, "k6_iterations_total", loadDuration)
+				value, err := q.QueryValue(ctx, query, loadEndTime)
+				ratio := value / rateDuration.Seconds() / endpoint.TargetRate
+				results = append(results, newSLOResult("minRateRatio", endpoint.Name, ratio, err,
+					windtunnelv1alpha1.SLOOperatorGreaterThanOrEqual, threshold,
+				))
+			} else {
+				// Executors not based on the arrival rate have no target rate to compare with
+				results = append(results, windtunnelv1alpha1.SLOResult{
+					Name:         "minRateRatio",
+					EndpointName: endpoint.Name,
+					Threshold:    fmt.Sprintf("%s %s", windtunnelv1alpha1.SLOOperatorGreaterThanOrEqual, formatFloat(threshold)),
+					Message:      "No target rate",
+				})
+			}
+		}
+	}
+
+	completionTime := experiment.Status.CompletionTime.Time
+	replacer := strings.NewReplacer(
+		"${namespace}", experiment.Namespace,
+		"${experiment}", experiment.Name,
+		"${range}", getRange(completionTime.Sub(experiment.Status.StartTime.Time)),
+	)
+	for _, assertion := range slo.Assertions {
+		threshold, _ := strconv.ParseFloat(assertion.Value, 64)
+		value, err := q.QueryValue(ctx, replacer.Replace(assertion.Query), completionTime)
+		results = append(results, newSLOResult(assertion.Name, "", value, err, assertion.Operator, threshold))
+	}
+
+	verdict := windtunnelv1alpha1.SLOPassed
+	for _, result := range results {
+		if !result.Passed {
+			verdict = windtunnelv1alpha1.SLOFailed
+			break
+		}
+	}
+	return verdict, results
+}
+
+// newSLOResult creates the result of a criterion by comparing the observed value with the threshold.
+func newSLOResult(name, endpointName string, value float64, err error, operator windtunnelv1alpha1.SLOOperator, threshold float64) windtunnelv1alpha1.SLOResult {
+	result := windtunnelv1alpha1.SLOResult{
+		Name:         name,
+		EndpointName: endpointName,
+		Threshold:    fmt.Sprintf("%s %s", operator, formatFloat(threshold)),
+	}
+	if err != nil {
+		if errors.Is(err, ErrNoData) {
+			result.Message = "No data"
+		} else {
+			result.Message = fmt.Sprintf("Cannot query the value: %s", err)
+		}
+		return result
+	}
+	result.Value = formatFloat(value)
+	result.Passed = compare(value, operator, threshold)
+	return result
+}
+
+// compare compares the value with the threshold using the operator.
+func compare(value float64, operator windtunnelv1alpha1.SLOOperator, threshold float64) bool {
+	switch operator {
+	case windtunnelv1alpha1.SLOOperatorLessThan:
+		return value < threshold
+	case windtunnelv1alpha1.SLOOperatorLessThanOrEqual:
+		return value <= threshold
+	case windtunnelv1alpha1.SLOOperatorGreaterThan:
+		return value > threshold
+	case windtunnelv1alpha1.SLOOperatorGreaterThanOrEqual:
+		return value >= threshold
+	case windtunnelv1alpha1.SLOOperatorEqual:
+		return value == threshold
+	case windtunnelv1alpha1.SLOOperatorNotEqual:
+		return value != threshold
+	}
+	return false
+}
+
+// formatFloat formats a float number in the shortest representation.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
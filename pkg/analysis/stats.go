@@ -0,0 +1,121 @@
+package analysis
+
+import (
+	"math"
+)
+
+// welchTTestLess returns the one-sided p-value of the Welch's t-test on whether the mean of sample a is less than
+// that of sample b. It returns false if either sample has less than 2 values.
+func welchTTestLess(a, b []float64) (float64, bool) {
+	if len(a) < 2 || len(b) < 2 {
+		return 0, false
+	}
+
+	meanA, varA := meanVariance(a)
+	meanB, varB := meanVariance(b)
+	seA := varA / float64(len(a))
+	seB := varB / float64(len(b))
+	se := seA + seB
+	if se == 0 {
+		// Both samples are constant
+		if meanA < meanB {
+			return 0, true
+		}
+		return 1, true
+	}
+
+	t := (meanA - meanB) / math.Sqrt(se)
+	// Welch–Satterthwaite equation
+	df := se * se / (seA*seA/float64(len(a)-1) + seB*seB/float64(len(b)-1))
+	// The two-sided p-value is split into the two tails of the symmetric t-distribution
+	twoSided := regularizedIncompleteBeta(df/2, 0.5, df/(df+t*t))
+	if t < 0 {
+		return twoSided / 2, true
+	}
+	return 1 - twoSided/2, true
+}
+
+// meanVariance returns the mean and the unbiased variance of a sample.
+func meanVariance(sample []float64) (float64, float64) {
+	var sum float64
+	for _, value := range sample {
+		sum += value
+	}
+	mean := sum / float64(len(sample))
+
+	var sumSquares float64
+	for _, value := range sample {
+		sumSquares += (value - mean) * (value - mean)
+	}
+	return mean, sumSquares / float64(len(sample)-1)
+}
+
+// regularizedIncompleteBeta returns the regularized incomplete beta function I_x(a, b).
+// See Numerical Recipes, section 6.4.
+func regularizedIncompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lgammaAB, _ := math.Lgamma(a + b)
+	lgammaA, _ := math.Lgamma(a)
+	lgammaB, _ := math.Lgamma(b)
+	front := math.Exp(lgammaAB - lgammaA - lgammaB + a*math.Log(x) + b*math.Log(1-x))
+	// The continued fraction converges rapidly for x < (a+1)/(a+b+2), use the symmetry relation otherwise
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction of the incomplete beta function by the modified Lentz's method.
+func betaContinuedFraction(a, b, x float64) float64 {
+	const (
+		maxIterations = 300
+		epsilon       = 1e-14
+		tiny          = 1e-300
+	)
+
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1.0; m <= maxIterations; m++ {
+		// Even step
+		numerator := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// Odd step
+		numerator = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
@@ -0,0 +1,160 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+)
+
+// statsTolerance is the maximum absolute error allowed against the reference values, which are computed by the
+// closed forms of the distributions or by the numerical integration of the density of the t-distribution.
+const statsTolerance = 1e-9
+
+func TestWelchTTestLess(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []float64
+		b    []float64
+		want float64
+	}{
+		{
+			// Example 1 of the Welch's t-test on Wikipedia, two-sided p-value 0.021
+			name: "equal variances",
+			a:    []float64{27.5, 21.0, 19.0, 23.6, 17.0, 17.9, 16.9, 20.1, 21.9, 22.6, 23.1, 19.6, 19.0, 21.7, 21.4},
+			b:    []float64{27.1, 22.0, 20.8, 23.4, 23.4, 23.5, 25.8, 22.0, 24.8, 20.2, 21.9, 22.1, 22.9, 20.5, 24.4},
+			want: 0.0106890007314,
+		},
+		{
+			// Example 3 of the Welch's t-test on Wikipedia, two-sided p-value 0.035
+			name: "unequal variances and sizes",
+			a:    []float64{19.8, 20.4, 19.6, 17.8, 18.5, 18.9, 18.3, 18.9, 19.5, 22.0},
+			b:    []float64{28.2, 26.6, 20.1, 23.3, 25.2, 22.1, 17.7, 27.6, 20.6, 13.7, 23.2, 17.5, 20.6, 18.0, 23.9, 21.6, 24.3, 20.4, 23.9, 13.3},
+			want: 0.017742265415,
+		},
+		{
+			name: "higher mean",
+			a:    []float64{28.2, 26.6, 20.1, 23.3, 25.2, 22.1, 17.7, 27.6, 20.6, 13.7, 23.2, 17.5, 20.6, 18.0, 23.9, 21.6, 24.3, 20.4, 23.9, 13.3},
+			b:    []float64{19.8, 20.4, 19.6, 17.8, 18.5, 18.9, 18.3, 18.9, 19.5, 22.0},
+			want: 0.982257734585,
+		},
+		{
+			// t = -1.5 with 8 degrees of freedom
+			name: "integer degrees of freedom",
+			a:    []float64{10, 12, 14, 16, 18},
+			b:    []float64{13, 15, 17, 19, 21},
+			want: 0.0860016459759,
+		},
+		{
+			// t = -3/sqrt(2) with 2 degrees of freedom, whose CDF is 1/2 + t/(2*sqrt(2+t^2))
+			name: "2 degrees of freedom",
+			a:    []float64{1, 3},
+			b:    []float64{4, 6},
+			want: 0.5 - 3/math.Sqrt(2)/(2*math.Sqrt(6.5)),
+		},
+		{
+			// t = -2 with 1 degree of freedom, i.e., the Cauchy distribution, whose CDF is 1/2 + atan(t)/pi
+			name: "1 degree of freedom",
+			a:    []float64{1, 3},
+			b:    []float64{4, 4},
+			want: 0.5 + math.Atan(-2)/math.Pi,
+		},
+		{
+			name: "same samples",
+			a:    []float64{1, 2, 3, 4},
+			b:    []float64{1, 2, 3, 4},
+			want: 0.5,
+		},
+		{
+			name: "constant samples with lower mean",
+			a:    []float64{5, 5, 5},
+			b:    []float64{6, 6},
+			want: 0,
+		},
+		{
+			name: "constant samples with higher mean",
+			a:    []float64{6, 6},
+			b:    []float64{5, 5, 5},
+			want: 1,
+		},
+		{
+			name: "constant samples with the same mean",
+			a:    []float64{5, 5, 5},
+			b:    []float64{5, 5},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := welchTTestLess(tt.a, tt.b)
+			if !ok {
+				t.Fatalf("got no p-value, want %v", tt.want)
+			}
+			if math.Abs(got-tt.want) > statsTolerance {
+				t.Errorf("got p-value %.12g, want %.12g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWelchTTestLessLargeDegreesOfFreedom(t *testing.T) {
+	a := make([]float64, 400)
+	b := make([]float64, 400)
+	for i := range a {
+		a[i] = float64(100 + (i*37)%11 - 5)
+		b[i] = 100.3 + float64((i*53)%11-5)
+	}
+	// t = -1.427878 with 797.999571 degrees of freedom
+	want := 0.0768591692567
+	got, ok := welchTTestLess(a, b)
+	if !ok || math.Abs(got-want) > statsTolerance {
+		t.Errorf("got p-value %.12g and %v, want %.12g", got, ok, want)
+	}
+}
+
+func TestWelchTTestLessTooFewValues(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []float64
+		b    []float64
+	}{
+		{"empty", nil, []float64{1, 2}},
+		{"single value", []float64{1, 2}, []float64{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := welchTTestLess(tt.a, tt.b); ok {
+				t.Errorf("got p-value %v, want none", got)
+			}
+		})
+	}
+}
+
+func TestRegularizedIncompleteBeta(t *testing.T) {
+	tests := []struct {
+		name string
+		a    float64
+		b    float64
+		x    float64
+		want float64
+	}{
+		// I_x(a, 1) = x^a
+		{"b = 1", 2.5, 1, 0.3, math.Pow(0.3, 2.5)},
+		{"b = 1 near 1", 2.5, 1, 0.95, math.Pow(0.95, 2.5)},
+		// I_x(1, b) = 1 - (1-x)^b
+		{"a = 1", 1, 3.5, 0.2, 1 - math.Pow(0.8, 3.5)},
+		{"a = 1 near 1", 1, 3.5, 0.9, 1 - math.Pow(0.1, 3.5)},
+		// I_x(1/2, 1/2) = 2/pi * asin(sqrt(x))
+		{"a = b = 1/2", 0.5, 0.5, 0.1, 2 / math.Pi * math.Asin(math.Sqrt(0.1))},
+		{"a = b = 1/2 near 1", 0.5, 0.5, 0.99, 2 / math.Pi * math.Asin(math.Sqrt(0.99))},
+		// I_x(a, a) = 1/2 at x = 1/2 by symmetry
+		{"symmetric", 40, 40, 0.5, 0.5},
+		{"x = 0", 3, 2, 0, 0},
+		{"x = 1", 3, 2, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := regularizedIncompleteBeta(tt.a, tt.b, tt.x); math.Abs(got-tt.want) > statsTolerance {
+				t.Errorf("got %.12g, want %.12g", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// Summarize queries the summary of the load generated for each endpoint of a completed Experiment over the time
+// between its start and completion. Fields without data are not set.
+func (q *Querier) Summarize(ctx context.Context, experiment *windtunnelv1alpha1.Experiment, endpoints []Endpoint) ([]windtunnelv1alpha1.EndpointSummary, error) {
+	completionTime := experiment.Status.CompletionTime.Time
+	duration := completionTime.Sub(experiment.Status.StartTime.Time)
+	loadDuration := duration
+	if experiment.Status.DrainingStartTime != nil {
+		loadDuration = experiment.Status.DrainingStartTime.Sub(experiment.Status.StartTime.Time)
+	}
+
+	summaries := make([]windtunnelv1alpha1.EndpointSummary, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		summary := windtunnelv1alpha1.EndpointSummary{
+			EndpointName: endpoint.Name,
+		}
+
+		requests, err := q.queryOptionalValue(ctx, getCounterQuery(experiment, endpoint.Name, "k6_iterations_total", duration), completionTime)
+		if err != nil {
+			return nil, err
+		}
+		if requests != nil {
+			summary.Requests = ptr.To(int64(*requests))
+			summary.AchievedRate = formatFloat(*requests / getRateDuration(experiment, endpoint.Name, loadDuration).Seconds())
+		}
+
+		latencies := []struct {
+			stat  string
+			field **metav1.Duration
+		}{
+			{"p50", &summary.LatencyP50},
+			{"p95", &summary.LatencyP95},
+			{"p99", &summary.LatencyP99},
+		}
+		for _, latency := range latencies {
+			value, err := q.queryOptionalValue(ctx, getLatencyQuery(experiment, endpoint.Name, endpoint.Protocol, latency.stat, duration), completionTime)
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				*latency.field = &metav1.Duration{Duration: time.Duration(*value * float64(time.Second)).Round(time.Microsecond)}
+			}
+		}
+
+		if endpoint.Protocol == windtunnelv1alpha1.EndpointProtocolHTTP {
+			httpErrors, err := q.QueryVector(ctx, getHTTPErrorsQuery(experiment, endpoint.Name, duration), completionTime, "status")
+			if err != nil {
+				return nil, err
+			}
+			if len(httpErrors) > 0 {
+				summary.HTTPErrors = make(map[string]int64, len(httpErrors))
+				for status, count := range httpErrors {
+					summary.HTTPErrors[status] = int64(count)
+				}
+			}
+		}
+
+		droppedIterations, err := q.queryOptionalValue(ctx, getCounterQuery(experiment, endpoint.Name, "k6_dropped_iterations_total", duration), completionTime)
+		if err != nil {
+			return nil, err
+		}
+		if droppedIterations != nil {
+			summary.DroppedIterations = ptr.To(int64(*droppedIterations))
+		} else if requests != nil {
+			// K6 does not write the counter until an iteration is dropped
+			summary.DroppedIterations = ptr.To(int64(0))
+		}
+
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// queryOptionalValue runs QueryValue and returns nil instead of ErrNoData if the query returns no sample.
+func (q *Querier) queryOptionalValue(ctx context.Context, query string, ts time.Time) (*float64, error) {
+	value, err := q.QueryValue(ctx, query, ts)
+	if errors.Is(err, ErrNoData) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
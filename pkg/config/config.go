@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sync"
 
 	"github.com/spf13/viper"
@@ -20,6 +21,10 @@ func init() {
 	viperInstance.SetConfigType("yaml")
 	viperInstance.AddConfigPath("./config/plantd") // Development
 	viperInstance.AddConfigPath("/etc/plantd")     // Production
+	// Development, when run outside the root of the repository, e.g., by the tests of other packages
+	if _, file, _, ok := runtime.Caller(0); ok {
+		viperInstance.AddConfigPath(filepath.Join(filepath.Dir(file), "..", "..", "config", "plantd"))
+	}
 	if err := viperInstance.ReadInConfig(); err != nil {
 		panic(fmt.Errorf("Cannot read config file: %s\n", err))
 	}
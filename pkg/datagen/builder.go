@@ -2,7 +2,9 @@ package datagen
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/brianvoe/gofakeit/v7"
 
@@ -20,6 +22,15 @@ type ColumnBuilder struct {
 	Formula Formula
 	// Parameters for formula
 	FormulaArgs []string
+	// Function creating a new formula each time the column is built, if the formula is stateful
+	NewFormula FormulaFactory
+	// Key of the referenced column
+	Reference string
+	// Expression evaluated by the formula, if the formula is an expression
+	Expression *Expression
+	// Go type of the generated values, empty if it is the type of the referenced column or the column referenced by
+	// the first formula argument
+	OutputType string
 }
 
 type SchemaBuilder struct {
@@ -29,12 +40,16 @@ type SchemaBuilder struct {
 	Path string
 	// ColumnBuilders of the SchemaBuilder
 	ColBuilders []*ColumnBuilder
+	// ColumnBuilders in the order they are built, after the ColumnBuilders they depend on
+	BuildOrder []*ColumnBuilder
 	// Number of records per file
 	NumRecords int
 	// Number of files per compressed file
 	NumFilesPerCompressedFile int
 	// Total number of records the SchemaBuilder should generate
 	TotalNumRecords int
+	// Maximum total number of records the SchemaBuilder can generate in a repetition
+	MaxTotalNumRecords int
 }
 
 type OutputBuilder struct {
@@ -48,6 +63,18 @@ type OutputBuilder struct {
 	Operations []Operation
 	// Whether compressed file should be created per Schema
 	CompressPerSchema bool
+	// Total size of the files written by the OutputBuilder
+	BytesWritten int64
+}
+
+// AddOutputFile adds the size of a file written by the OutputBuilder to the total size.
+func (ob *OutputBuilder) AddOutputFile(filePath string) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	ob.BytesWritten += info.Size()
+	return nil
 }
 
 // PutParams creates a gofakeit.MapParams instance based on the provided column and parameters.
@@ -74,6 +101,16 @@ func NewSchemaBuilder(schema *windtunnelv1alpha1.Schema) (*SchemaBuilder, error)
 	}
 	colNames := make([]string, numCol)
 	for i, col := range schema.Spec.Columns {
+		// Reference overrides the gofakeit function and formula
+		if col.Reference != nil {
+			schBldr.ColBuilders[i] = &ColumnBuilder{
+				Name:      col.Name,
+				Reference: GetReferenceKey(col.Reference),
+			}
+			colNames[i] = GetKey(&schBldr, schBldr.ColBuilders[i])
+			continue
+		}
+
 		info := gofakeit.GetFuncLookup(col.Type)
 		formula := GetFormulaLookup(col.Formula.Name)
 		var infoParams *gofakeit.MapParams
@@ -83,29 +120,144 @@ func NewSchemaBuilder(schema *windtunnelv1alpha1.Schema) (*SchemaBuilder, error)
 			return nil, ColumnError(col.Name)
 		}
 
+		// Formula overrides the gofakeit function, so does its output type
+		var outputType string
+		if formula != nil {
+			outputType = GetFormulaOutputType(col.Formula.Name)
+		} else {
+			outputType = info.Output
+		}
+
+		// Expressions are compiled once, their output types are known after type checking
+		var expr *Expression
+		if col.Formula.Name == ExpressionFormulaName {
+			if len(col.Formula.Args) != 1 {
+				return nil, FormulaArgsError(fmt.Sprintf("%s.%s", schema.Name, col.Name))
+			}
+			var err error
+			expr, err = ParseExpression(col.Formula.Args[0])
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", schema.Name, col.Name, err)
+			}
+			formula = expr.Formula(schema.Name)
+		}
+
 		schBldr.ColBuilders[i] = &ColumnBuilder{
 			Name:          col.Name,
 			Info:          info,
 			InfoMapParams: infoParams,
 			Formula:       formula,
 			FormulaArgs:   col.Formula.Args,
+			NewFormula:    GetFormulaFactoryLookup(col.Formula.Name),
+			Expression:    expr,
+			OutputType:    outputType,
 		}
 
 		colNames[i] = GetKey(&schBldr, schBldr.ColBuilders[i])
 	}
 
+	// Columns are built after the columns they depend on, no matter the order they are declared in
+	buildOrder, err := SortColumnsByDependency(schema)
+	if err != nil {
+		return nil, err
+	}
+	schBldr.BuildOrder = make([]*ColumnBuilder, numCol)
+	for i, idx := range buildOrder {
+		schBldr.BuildOrder[i] = schBldr.ColBuilders[idx]
+	}
+
+	// Expressions are type checked in the build order, so that the types of the columns they use are known. Columns
+	// of this Schema are looked up in the new SchemaBuilder, and the others in the schema builder cache.
+	prefixPattern := schema.Name + "."
+	lookup := func(key string) *ColumnBuilder {
+		if strings.HasPrefix(key, prefixPattern) {
+			for _, colBldr := range schBldr.ColBuilders {
+				if prefixPattern+colBldr.Name == key {
+					return colBldr
+				}
+			}
+			return nil
+		}
+		return getColumnBuilder(key)
+	}
+	for _, colBldr := range schBldr.BuildOrder {
+		if colBldr.Expression == nil {
+			continue
+		}
+		if err := colBldr.Expression.Check(func(name string) (string, error) {
+			return resolveOutputType(prefixPattern+name, lookup)
+		}); err != nil {
+			return nil, fmt.Errorf("%s%s: %w", prefixPattern, colBldr.Name, err)
+		}
+		colBldr.OutputType = colBldr.Expression.OutputType()
+	}
+
 	PutColumnNames(schema.Name, colNames)
 	return &schBldr, nil
 }
 
-// Build generates fake data based on the provided SchemaBuilder.
-func (schBldr *SchemaBuilder) Build(faker *gofakeit.Faker) error {
-	for _, colBldr := range schBldr.ColBuilders {
+// GetOutputType returns the Go type of the values generated for a column, identified by its key. Columns that
+// reference another column, or whose formula copies another column, are resolved to the type of that column.
+func GetOutputType(key string) (string, error) {
+	return resolveOutputType(key, getColumnBuilder)
+}
+
+// resolveOutputType resolves the Go type of the values generated for a column, using the given function to look up
+// ColumnBuilders by their keys.
+func resolveOutputType(key string, lookup func(key string) *ColumnBuilder) (string, error) {
+	// Stop the resolution if columns copy each other
+	visited := make(map[string]bool)
+	for !visited[key] {
+		visited[key] = true
+		colBldr := lookup(key)
+		if colBldr == nil {
+			return "", ResourceNotFoundError(key)
+		}
+		if colBldr.OutputType != "" {
+			return colBldr.OutputType, nil
+		}
+		if colBldr.Reference != "" {
+			key = colBldr.Reference
+			continue
+		}
+		if len(colBldr.FormulaArgs) == 0 {
+			return "", FormulaArgsError(key)
+		}
+		key = colBldr.FormulaArgs[0]
+	}
+	return "", TypeError(key)
+}
+
+// getColumnBuilder retrieves a ColumnBuilder from the schema builder cache by its key.
+func getColumnBuilder(key string) *ColumnBuilder {
+	for _, schBldr := range schemaBuilderCache {
+		for _, colBldr := range schBldr.ColBuilders {
+			if GetKey(schBldr, colBldr) == key {
+				return colBldr
+			}
+		}
+	}
+	return nil
+}
+
+// Build generates fake data based on the provided SchemaBuilder for the repetition with the given sequence number.
+func (schBldr *SchemaBuilder) Build(faker *gofakeit.Faker, repeatSeq int) error {
+	for _, colBldr := range schBldr.BuildOrder {
 		// Prepare fake data in the cache for this column
 		var fakeData interface{}
 		var err error
 		key := GetKey(schBldr, colBldr)
 
+		if colBldr.Reference != "" {
+			for i := 0; i < schBldr.TotalNumRecords; i++ {
+				fakeData, err = GetFakeDataFromRandomRecord(faker, colBldr.Reference)
+				if err != nil {
+					return err
+				}
+				PutFakeData(key, i, fakeData)
+			}
+		}
+
 		if colBldr.Info != nil {
 			for i := 0; i < schBldr.TotalNumRecords; i++ {
 				fakeData, err = colBldr.Info.Generate(faker, colBldr.InfoMapParams, colBldr.Info)
@@ -117,8 +269,17 @@ func (schBldr *SchemaBuilder) Build(faker *gofakeit.Faker) error {
 		}
 
 		if colBldr.Formula != nil {
+			// Stateful formulas are created again in each build, and continue from the previous repetitions
+			formula := colBldr.Formula
+			if colBldr.NewFormula != nil {
+				formula = colBldr.NewFormula(FormulaContext{
+					Key:           key,
+					RepeatSeq:     repeatSeq,
+					MaxNumRecords: schBldr.MaxTotalNumRecords,
+				})
+			}
 			for i := 0; i < schBldr.TotalNumRecords; i++ {
-				fakeData, err = colBldr.Formula(faker, i, colBldr.FormulaArgs...)
+				fakeData, err = formula(faker, i, colBldr.FormulaArgs...)
 				if err != nil {
 					return err
 				}
@@ -182,11 +343,13 @@ func NewOutputBuilder(dataSet *windtunnelv1alpha1.DataSet, path string) (*Output
 func (outBldr *OutputBuilder) SetRandomnessAndCache(faker *gofakeit.Faker, dataSet *windtunnelv1alpha1.DataSet) {
 	for i, sch := range dataSet.Spec.Schemas {
 		outBldr.SchBuilders[i].NumRecords = faker.Number(int(sch.NumRecords.Min), int(sch.NumRecords.Max))
-		outBldr.SchBuilders[i].NumFilesPerCompressedFile = faker.Number(int(sch.NumRecords.Min), int(sch.NumRecords.Max))
+		outBldr.SchBuilders[i].NumFilesPerCompressedFile = faker.Number(int(sch.NumFilesPerCompressedFile.Min), int(sch.NumFilesPerCompressedFile.Max))
 		if dataSet.Spec.CompressedFileFormat == "" {
 			outBldr.SchBuilders[i].TotalNumRecords = outBldr.SchBuilders[i].NumRecords
+			outBldr.SchBuilders[i].MaxTotalNumRecords = int(sch.NumRecords.Max)
 		} else {
 			outBldr.SchBuilders[i].TotalNumRecords = outBldr.SchBuilders[i].NumRecords * outBldr.SchBuilders[i].NumFilesPerCompressedFile
+			outBldr.SchBuilders[i].MaxTotalNumRecords = int(sch.NumRecords.Max) * int(sch.NumFilesPerCompressedFile.Max)
 		}
 	}
 
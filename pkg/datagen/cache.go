@@ -1,6 +1,10 @@
 package datagen
 
-import "github.com/brianvoe/gofakeit/v7"
+import (
+	"github.com/brianvoe/gofakeit/v7"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+)
 
 type SchemaBuilderCache map[string]*SchemaBuilder
 type ColumnNamesCache map[string][]string
@@ -35,6 +39,11 @@ func GetKey(schBldr *SchemaBuilder, colBldr *ColumnBuilder) string {
 	return schBldr.SchemaName + "." + colBldr.Name
 }
 
+// GetReferenceKey generates the key of the column referenced by a ColumnReference.
+func GetReferenceKey(ref *windtunnelv1alpha1.ColumnReference) string {
+	return ref.Schema + "." + ref.Column
+}
+
 // NewFakeDataCache creates a new fake data cache based on the provided output builder.
 func NewFakeDataCache(outputBuilder *OutputBuilder) {
 	mapLen := 0
@@ -98,6 +107,9 @@ func GetFakeData(key string, recordID int) (interface{}, error) {
 // GetFakeDataFromRandomRecord retrieves fake data from the fake data cache for a specific key and a random record ID.
 func GetFakeDataFromRandomRecord(faker *gofakeit.Faker, key string) (interface{}, error) {
 	if colDataList, ok := fakeDataCache[key]; ok {
+		if len(colDataList) == 0 {
+			return nil, OutOfIndexError(key)
+		}
 		recordID := faker.Number(0, len(colDataList)-1)
 		return colDataList[recordID], nil
 	}
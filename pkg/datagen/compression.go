@@ -0,0 +1,236 @@
+package datagen
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressionLookups maps compressed file formats to their corresponding compressions.
+var compressionLookups = map[string]Compression{
+	"zip":    {Extension: "zip", NewWriter: newZipFileWriter},
+	"gzip":   {Extension: "gz", NewWriter: newGzipFileWriter},
+	"tar.gz": {Extension: "tar.gz", NewWriter: newTarGzipFileWriter},
+	"zstd":   {Extension: "zst", NewWriter: newZstdFileWriter},
+}
+
+// Compression defines how compressed files of a format are named and written.
+type Compression struct {
+	// Extension of the compressed files
+	Extension string
+	// Function creating a CompressedFileWriter on top of a writer
+	NewWriter func(w io.Writer) (CompressedFileWriter, error)
+}
+
+// CompressedFileWriter writes files into a compressed file.
+type CompressedFileWriter interface {
+	// Create adds a file with the given name to the compressed file and returns a writer for its content.
+	// The content must be written before the next call to Create or Close.
+	Create(name string) (io.Writer, error)
+	// Close finishes writing the compressed file. It does not close the underlying writer.
+	Close() error
+}
+
+// CompressedFileSchemaWriter represents a function that writes the files of a specific schema stored in cache
+// into a compressed file.
+type CompressedFileSchemaWriter func(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int,
+	cw CompressedFileWriter) error
+
+// NewCompressionOperation creates an operation that writes the files stored in cache into compressed files.
+func NewCompressionOperation(compression Compression, writeBySchema CompressedFileSchemaWriter) Operation {
+	return func(outputBuilder *OutputBuilder, seqNum int) error {
+		return AtCache2CompressedFile(outputBuilder, seqNum, compression, writeBySchema)
+	}
+}
+
+// AtCache2CompressedFile writes the files stored in cache into compressed files, either one compressed file
+// per Schema or one compressed file for all Schemas, depending on the OutputBuilder.
+func AtCache2CompressedFile(outputBuilder *OutputBuilder, seqNum int, compression Compression,
+	writeBySchema CompressedFileSchemaWriter) error {
+	if outputBuilder.CompressPerSchema {
+		for _, schBldr := range outputBuilder.SchBuilders {
+			filePath := filepath.Join(outputBuilder.Path, fmt.Sprintf("%s_%s_%d.%s", outputBuilder.Name, schBldr.SchemaName, seqNum, compression.Extension))
+			if err := writeCompressedFile(filePath, compression, func(cw CompressedFileWriter) error {
+				return writeBySchema(schBldr.SchemaName, seqNum, schBldr.NumFilesPerCompressedFile, schBldr.NumRecords, cw)
+			}); err != nil {
+				return err
+			}
+			if err := outputBuilder.AddOutputFile(filePath); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	filePath := filepath.Join(outputBuilder.Path, fmt.Sprintf("%s_%d.%s", outputBuilder.Name, seqNum, compression.Extension))
+	if err := writeCompressedFile(filePath, compression, func(cw CompressedFileWriter) error {
+		for _, schBldr := range outputBuilder.SchBuilders {
+			if err := writeBySchema(schBldr.SchemaName, seqNum, schBldr.NumFilesPerCompressedFile, schBldr.NumRecords, cw); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return outputBuilder.AddOutputFile(filePath)
+}
+
+// writeCompressedFile creates a compressed file and writes its content with the given function.
+func writeCompressedFile(filePath string, compression Compression, write func(cw CompressedFileWriter) error) error {
+	outFile, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	cw, err := compression.NewWriter(outFile)
+	if err != nil {
+		return err
+	}
+	if err := write(cw); err != nil {
+		cw.Close()
+		return err
+	}
+	return cw.Close()
+}
+
+// newZipFileWriter creates a CompressedFileWriter writing a zip archive.
+func newZipFileWriter(w io.Writer) (CompressedFileWriter, error) {
+	return zip.NewWriter(w), nil
+}
+
+// gzipFileWriter writes each file as a separate member of a gzip stream, with the name of the file in the member
+// header. The DataSet webhook ensures that there is only one file, so that the stream decompresses to it.
+type gzipFileWriter struct {
+	w  io.Writer
+	gw *gzip.Writer
+}
+
+// newGzipFileWriter creates a CompressedFileWriter writing a multi-member gzip stream.
+func newGzipFileWriter(w io.Writer) (CompressedFileWriter, error) {
+	return &gzipFileWriter{w: w}, nil
+}
+
+// Create implements CompressedFileWriter.
+func (g *gzipFileWriter) Create(name string) (io.Writer, error) {
+	if g.gw != nil {
+		if err := g.gw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	g.gw = gzip.NewWriter(g.w)
+	g.gw.Name = name
+	return g.gw, nil
+}
+
+// Close implements CompressedFileWriter.
+func (g *gzipFileWriter) Close() error {
+	if g.gw == nil {
+		return nil
+	}
+	return g.gw.Close()
+}
+
+// tarGzipFileWriter writes files into a gzip-compressed tar archive. The content of each file is buffered, as the
+// tar header must contain the size of the file.
+type tarGzipFileWriter struct {
+	gw   *gzip.Writer
+	tw   *tar.Writer
+	name string
+	buff *bytes.Buffer
+}
+
+// newTarGzipFileWriter creates a CompressedFileWriter writing a gzip-compressed tar archive.
+func newTarGzipFileWriter(w io.Writer) (CompressedFileWriter, error) {
+	gw := gzip.NewWriter(w)
+	return &tarGzipFileWriter{
+		gw: gw,
+		tw: tar.NewWriter(gw),
+	}, nil
+}
+
+// Create implements CompressedFileWriter.
+func (t *tarGzipFileWriter) Create(name string) (io.Writer, error) {
+	if err := t.flush(); err != nil {
+		return nil, err
+	}
+	t.name = name
+	t.buff = &bytes.Buffer{}
+	return t.buff, nil
+}
+
+// Close implements CompressedFileWriter.
+func (t *tarGzipFileWriter) Close() error {
+	if err := t.flush(); err != nil {
+		return err
+	}
+	if err := t.tw.Close(); err != nil {
+		return err
+	}
+	return t.gw.Close()
+}
+
+// flush writes the buffered file into the tar archive.
+func (t *tarGzipFileWriter) flush() error {
+	if t.buff == nil {
+		return nil
+	}
+	if err := t.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     t.name,
+		Mode:     0644,
+		Size:     int64(t.buff.Len()),
+	}); err != nil {
+		return err
+	}
+	if _, err := t.tw.Write(t.buff.Bytes()); err != nil {
+		return err
+	}
+	t.buff = nil
+	return nil
+}
+
+// zstdFileWriter writes each file as a separate frame of a zstd stream. The DataSet webhook ensures that there is
+// only one file, so that the stream decompresses to it.
+type zstdFileWriter struct {
+	w       io.Writer
+	enc     *zstd.Encoder
+	started bool
+}
+
+// newZstdFileWriter creates a CompressedFileWriter writing a multi-frame zstd stream.
+func newZstdFileWriter(w io.Writer) (CompressedFileWriter, error) {
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdFileWriter{w: w, enc: enc}, nil
+}
+
+// Create implements CompressedFileWriter.
+func (z *zstdFileWriter) Create(name string) (io.Writer, error) {
+	if z.started {
+		if err := z.enc.Close(); err != nil {
+			return nil, err
+		}
+	}
+	z.enc.Reset(z.w)
+	z.started = true
+	return z.enc, nil
+}
+
+// Close implements CompressedFileWriter.
+func (z *zstdFileWriter) Close() error {
+	if !z.started {
+		return nil
+	}
+	return z.enc.Close()
+}
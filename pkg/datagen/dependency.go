@@ -0,0 +1,184 @@
+package datagen
+
+import (
+	"strings"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+)
+
+// GetSchemaDependencies returns the names of the other Schemas that a Schema depends on, either through column
+// references or through formula arguments referring to their columns. schemaMap contains all Schemas in the
+// DataSet, and references to columns outside of it cause an error.
+func GetSchemaDependencies(schema *windtunnelv1alpha1.Schema, schemaMap map[string]*windtunnelv1alpha1.Schema) ([]string, error) {
+	// Map keys of all columns in the DataSet to the names of their Schemas
+	colKeys := make(map[string]string)
+	for schemaName, schemaObj := range schemaMap {
+		for _, col := range schemaObj.Spec.Columns {
+			colKeys[schemaName+"."+col.Name] = schemaName
+		}
+	}
+
+	var deps []string
+	addDep := func(schemaName string) {
+		if schemaName == schema.Name {
+			return
+		}
+		for _, dep := range deps {
+			if dep == schemaName {
+				return
+			}
+		}
+		deps = append(deps, schemaName)
+	}
+
+	for _, col := range schema.Spec.Columns {
+		if col.Reference != nil {
+			key := GetReferenceKey(col.Reference)
+			schemaName, ok := colKeys[key]
+			if !ok {
+				return nil, ReferenceError(key)
+			}
+			addDep(schemaName)
+			continue
+		}
+		// Formula arguments are not necessarily column keys, only those matching a column are dependencies
+		for _, arg := range col.Formula.Args {
+			if schemaName, ok := colKeys[arg]; ok {
+				addDep(schemaName)
+			}
+		}
+	}
+	return deps, nil
+}
+
+// SortSchemasByDependency sorts the names of the Schemas in a DataSet, so that each Schema comes after the Schemas
+// it depends on. Schemas not depending on each other keep their original order. An error is returned if the
+// dependencies form a cycle.
+func SortSchemasByDependency(schemaNames []string, schemaMap map[string]*windtunnelv1alpha1.Schema) ([]string, error) {
+	// Only Schemas in the DataSet can be depended on
+	dataSetSchemaMap := make(map[string]*windtunnelv1alpha1.Schema, len(schemaNames))
+	for _, schemaName := range schemaNames {
+		schemaObj, ok := schemaMap[schemaName]
+		if !ok {
+			return nil, SchemaUndefinedError(schemaName)
+		}
+		dataSetSchemaMap[schemaName] = schemaObj
+	}
+
+	deps := make([][]string, len(schemaNames))
+	for i, schemaName := range schemaNames {
+		schemaDeps, err := GetSchemaDependencies(dataSetSchemaMap[schemaName], dataSetSchemaMap)
+		if err != nil {
+			return nil, err
+		}
+		deps[i] = schemaDeps
+	}
+
+	order, err := sortByDependency(schemaNames, deps)
+	if err != nil {
+		return nil, err
+	}
+	sorted := make([]string, len(order))
+	for i, idx := range order {
+		sorted[i] = schemaNames[idx]
+	}
+	return sorted, nil
+}
+
+// GetColumnDependencies returns the keys of the other columns in the same Schema that a column depends on, either
+// through a reference, through formula arguments referring to them, or through the columns used in an expression.
+func GetColumnDependencies(schemaName string, col windtunnelv1alpha1.Column) []string {
+	prefixPattern := schemaName + "."
+	var deps []string
+	if col.Reference != nil {
+		if col.Reference.Schema == schemaName {
+			deps = append(deps, GetReferenceKey(col.Reference))
+		}
+		return deps
+	}
+	if col.Formula.Name == ExpressionFormulaName {
+		// Invalid expressions have no dependencies, the error is reported when they are compiled
+		if len(col.Formula.Args) == 1 {
+			if expr, err := ParseExpression(col.Formula.Args[0]); err == nil {
+				for _, name := range expr.Columns() {
+					deps = append(deps, prefixPattern+name)
+				}
+			}
+		}
+		return deps
+	}
+	for _, arg := range col.Formula.Args {
+		if strings.HasPrefix(arg, prefixPattern) {
+			deps = append(deps, arg)
+		}
+	}
+	return deps
+}
+
+// SortColumnsByDependency returns the indexes of the columns in a Schema, sorted so that each column comes after
+// the columns it depends on. Columns not depending on each other keep their declaration order. An error is returned
+// if the dependencies form a cycle.
+func SortColumnsByDependency(schema *windtunnelv1alpha1.Schema) ([]int, error) {
+	colKeys := make([]string, len(schema.Spec.Columns))
+	deps := make([][]string, len(schema.Spec.Columns))
+	for i, col := range schema.Spec.Columns {
+		colKeys[i] = schema.Name + "." + col.Name
+		deps[i] = GetColumnDependencies(schema.Name, col)
+	}
+	return sortByDependency(colKeys, deps)
+}
+
+// sortByDependency sorts the indexes of the named nodes, so that each node comes after the nodes it depends on.
+// Dependencies on names not in the list are ignored. Nodes not depending on each other keep their original order.
+// An error containing the path of the cycle is returned if the dependencies form a cycle.
+func sortByDependency(names []string, deps [][]string) ([]int, error) {
+	indexes := make(map[string]int, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		indexes[names[i]] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make([]int, len(names))
+	sorted := make([]int, 0, len(names))
+	var path []string
+
+	// Depth-first search, appending each node after all its dependencies
+	var visit func(idx int) error
+	visit = func(idx int) error {
+		switch states[idx] {
+		case visited:
+			return nil
+		case visiting:
+			for i, name := range path {
+				if name == names[idx] {
+					return DependencyCycleError(strings.Join(append(path[i:], names[idx]), " -> "))
+				}
+			}
+		}
+
+		states[idx] = visiting
+		path = append(path, names[idx])
+		for _, dep := range deps[idx] {
+			if depIdx, ok := indexes[dep]; ok {
+				if err := visit(depIdx); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		states[idx] = visited
+		sorted = append(sorted, idx)
+		return nil
+	}
+
+	for idx := range names {
+		if err := visit(idx); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
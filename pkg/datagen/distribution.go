@@ -0,0 +1,262 @@
+package datagen
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// distributionCategory is the gofakeit category of the distributions.
+const distributionCategory = "distribution"
+
+func init() {
+	initDistributionLookups()
+}
+
+// initDistributionLookups registers the distributions as gofakeit functions, so that they can be used as column
+// types, with their parameters set through the column parameters, like any other gofakeit function.
+func initDistributionLookups() {
+	gofakeit.AddFuncLookup("normal", gofakeit.Info{
+		Display:     "Normal",
+		Category:    distributionCategory,
+		Description: "Random number from a normal distribution",
+		Example:     "101.27",
+		Output:      "float64",
+		Params: []gofakeit.Param{
+			{Field: "mean", Display: "Mean", Type: "float", Default: "0", Description: "Mean of the distribution"},
+			{Field: "stddev", Display: "Standard Deviation", Type: "float", Default: "1", Description: "Standard deviation of the distribution"},
+		},
+		Generate: Normal,
+	})
+	gofakeit.AddFuncLookup("lognormal", gofakeit.Info{
+		Display:     "Log-normal",
+		Category:    distributionCategory,
+		Description: "Random number from a log-normal distribution, whose logarithm is normally distributed",
+		Example:     "2.13",
+		Output:      "float64",
+		Params: []gofakeit.Param{
+			{Field: "mu", Display: "Mu", Type: "float", Default: "0", Description: "Mean of the logarithm of the distribution"},
+			{Field: "sigma", Display: "Sigma", Type: "float", Default: "1", Description: "Standard deviation of the logarithm of the distribution"},
+		},
+		Generate: LogNormal,
+	})
+	gofakeit.AddFuncLookup("exponential", gofakeit.Info{
+		Display:     "Exponential",
+		Category:    distributionCategory,
+		Description: "Random number from an exponential distribution",
+		Example:     "0.74",
+		Output:      "float64",
+		Params: []gofakeit.Param{
+			{Field: "rate", Display: "Rate", Type: "float", Default: "1", Description: "Rate of the distribution, the inverse of its mean"},
+		},
+		Generate: Exponential,
+	})
+	gofakeit.AddFuncLookup("poisson", gofakeit.Info{
+		Display:     "Poisson",
+		Category:    distributionCategory,
+		Description: "Random number of events from a Poisson distribution",
+		Example:     "3",
+		Output:      "int",
+		Params: []gofakeit.Param{
+			{Field: "lambda", Display: "Lambda", Type: "float", Default: "1", Description: "Mean number of events"},
+		},
+		Generate: Poisson,
+	})
+	gofakeit.AddFuncLookup("zipf", gofakeit.Info{
+		Display:     "Zipf",
+		Category:    distributionCategory,
+		Description: "Random rank from a Zipf distribution, where the probability of rank k is proportional to (v + k) ** -s",
+		Example:     "0",
+		Output:      "int",
+		Params: []gofakeit.Param{
+			{Field: "s", Display: "S", Type: "float", Default: "1.1", Description: "Exponent of the distribution, must be greater than 1"},
+			{Field: "v", Display: "V", Type: "float", Default: "1", Description: "Offset of the ranks, must be at least 1"},
+			{Field: "max", Display: "Max", Type: "uint", Default: "100", Description: "Maximum rank"},
+		},
+		Generate: Zipf,
+	})
+	gofakeit.AddFuncLookup("categorical", gofakeit.Info{
+		Display:     "Categorical",
+		Category:    distributionCategory,
+		Description: "Random value from a list of values, with probabilities proportional to their weights",
+		Example:     "small,medium,large 6,3,1 => small",
+		Output:      "string",
+		Params: []gofakeit.Param{
+			{Field: "values", Display: "Values", Type: "string", Description: "Comma-separated list of values"},
+			{Field: "weights", Display: "Weights", Type: "string", Optional: true, Description: "Comma-separated list of weights, one per value, equal if empty"},
+		},
+		Generate: Categorical,
+	})
+}
+
+// distributionRand creates a rand.Rand drawing from the source of the faker, so that the distributions are
+// reproducible with the same seed.
+func distributionRand(f *gofakeit.Faker) *rand.Rand {
+	return rand.New(f.Rand)
+}
+
+// Normal generates a random number from a normal distribution.
+func Normal(f *gofakeit.Faker, m *gofakeit.MapParams, info *gofakeit.Info) (interface{}, error) {
+	mean, err := info.GetFloat64(m, "mean")
+	if err != nil {
+		return nil, err
+	}
+	stddev, err := info.GetFloat64(m, "stddev")
+	if err != nil {
+		return nil, err
+	}
+	if stddev < 0 {
+		return nil, ParamError("normal.stddev")
+	}
+	return mean + stddev*distributionRand(f).NormFloat64(), nil
+}
+
+// LogNormal generates a random number from a log-normal distribution.
+func LogNormal(f *gofakeit.Faker, m *gofakeit.MapParams, info *gofakeit.Info) (interface{}, error) {
+	mu, err := info.GetFloat64(m, "mu")
+	if err != nil {
+		return nil, err
+	}
+	sigma, err := info.GetFloat64(m, "sigma")
+	if err != nil {
+		return nil, err
+	}
+	if sigma < 0 {
+		return nil, ParamError("lognormal.sigma")
+	}
+	return math.Exp(mu + sigma*distributionRand(f).NormFloat64()), nil
+}
+
+// Exponential generates a random number from an exponential distribution.
+func Exponential(f *gofakeit.Faker, m *gofakeit.MapParams, info *gofakeit.Info) (interface{}, error) {
+	rate, err := info.GetFloat64(m, "rate")
+	if err != nil {
+		return nil, err
+	}
+	if rate <= 0 {
+		return nil, ParamError("exponential.rate")
+	}
+	return distributionRand(f).ExpFloat64() / rate, nil
+}
+
+// Poisson generates a random number of events from a Poisson distribution. Small means use Knuth's multiplication
+// method, and large means use the transformed rejection method of Hörmann, whose cost does not grow with the mean.
+func Poisson(f *gofakeit.Faker, m *gofakeit.MapParams, info *gofakeit.Info) (interface{}, error) {
+	lambda, err := info.GetFloat64(m, "lambda")
+	if err != nil {
+		return nil, err
+	}
+	if lambda < 0 {
+		return nil, ParamError("poisson.lambda")
+	}
+	r := distributionRand(f)
+
+	if lambda < 10 {
+		limit := math.Exp(-lambda)
+		k := 0
+		for p := r.Float64(); p > limit; p *= r.Float64() {
+			k++
+		}
+		return k, nil
+	}
+
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := r.Float64() - 0.5
+		v := r.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return int(k), nil
+		}
+		if k < 0 || us < 0.013 && v > us {
+			continue
+		}
+		lgamma, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -lambda+k*loglam-lgamma {
+			return int(k), nil
+		}
+	}
+}
+
+// Zipf generates a random rank from a Zipf distribution.
+func Zipf(f *gofakeit.Faker, m *gofakeit.MapParams, info *gofakeit.Info) (interface{}, error) {
+	s, err := info.GetFloat64(m, "s")
+	if err != nil {
+		return nil, err
+	}
+	v, err := info.GetFloat64(m, "v")
+	if err != nil {
+		return nil, err
+	}
+	imax, err := info.GetUint(m, "max")
+	if err != nil {
+		return nil, err
+	}
+	zipf := rand.NewZipf(distributionRand(f), s, v, uint64(imax))
+	if zipf == nil {
+		return nil, ParamError("zipf.s, zipf.v")
+	}
+	return int(zipf.Uint64()), nil
+}
+
+// Categorical generates a random value from a list of values, with probabilities proportional to their weights.
+func Categorical(f *gofakeit.Faker, m *gofakeit.MapParams, info *gofakeit.Info) (interface{}, error) {
+	valuesParam, err := info.GetString(m, "values")
+	if err != nil {
+		return nil, err
+	}
+	if valuesParam == "" {
+		return nil, ParamError("categorical.values")
+	}
+	values := strings.Split(valuesParam, ",")
+
+	weights := make([]float64, len(values))
+	weightsParam, err := info.GetString(m, "weights")
+	if err != nil || weightsParam == "" {
+		for i := range weights {
+			weights[i] = 1
+		}
+	} else {
+		weightStrs := strings.Split(weightsParam, ",")
+		if len(weightStrs) != len(values) {
+			return nil, ParamError(fmt.Sprintf("categorical.weights, expect %d weights, but got %d", len(values), len(weightStrs)))
+		}
+		for i, weightStr := range weightStrs {
+			weights[i], err = strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+			if err != nil || weights[i] < 0 {
+				return nil, ParamError("categorical.weights")
+			}
+		}
+	}
+
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	if total == 0 {
+		return nil, ParamError("categorical.weights")
+	}
+	target := f.Float64() * total
+	for i, weight := range weights {
+		target -= weight
+		if target < 0 {
+			return strings.TrimSpace(values[i]), nil
+		}
+	}
+	// Rounding errors may leave the target slightly above the total, fall back to the last value that can be chosen
+	for i := len(values) - 1; ; i-- {
+		if weights[i] > 0 {
+			return strings.TrimSpace(values[i]), nil
+		}
+	}
+}
@@ -11,6 +11,10 @@ type OperationUndefinedError string
 type OutOfIndexError string
 type ResourceNotFoundError string
 type FormulaArgsError string
+type ReferenceError string
+type DependencyCycleError string
+type ExpressionError string
+type ParamError string
 
 type NumParamError int
 
@@ -45,3 +49,19 @@ func (e NumParamError) Error() string {
 func (e FormulaArgsError) Error() string {
 	return "Formula got wrong arguments: " + string(e)
 }
+
+func (e ReferenceError) Error() string {
+	return "Reference Undefined: " + string(e)
+}
+
+func (e DependencyCycleError) Error() string {
+	return "Dependency Cycle Detected: " + string(e)
+}
+
+func (e ExpressionError) Error() string {
+	return "Expression Invalid: " + string(e)
+}
+
+func (e ParamError) Error() string {
+	return "Parameter got wrong value: " + string(e)
+}
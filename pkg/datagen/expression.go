@@ -0,0 +1,1035 @@
+package datagen
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// ExpressionFormulaName is the name of the formula evaluating an expression over other columns of the same record.
+const ExpressionFormulaName = "Expression"
+
+// exprType represents the type of a value in an expression, named after the Go type used for it.
+type exprType string
+
+const (
+	exprInt    exprType = "int"
+	exprFloat  exprType = "float64"
+	exprString exprType = "string"
+	exprBool   exprType = "bool"
+	exprTime   exprType = "time.Time"
+	// exprNumber and exprAny are only used in function signatures
+	exprNumber exprType = "number"
+	exprAny    exprType = "any"
+)
+
+// dateLayouts are the layouts accepted when parsing strings to dates.
+var dateLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"}
+
+// Expression is an expression evaluated over other columns of the same record. The language has integer, float,
+// string, boolean and date values, arithmetic, comparison and logical operators, ternaries, and a fixed set of
+// functions. It cannot access anything but the columns and always terminates, as it has no loops or assignments.
+type Expression struct {
+	// Source code of the expression
+	Source string
+	// Root node of the syntax tree
+	root exprNode
+	// Names of the columns used in the expression, in the order they first appear
+	columns []string
+	// Type of the result, empty until the expression is type checked
+	outputType exprType
+}
+
+// ParseExpression parses the source code of an expression. The types of the columns are not known yet, so the
+// expression must be type checked with Check before it is evaluated.
+func ParseExpression(source string) (*Expression, error) {
+	tokens, err := tokenizeExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != exprTokenEOF {
+		return nil, unexpectedTokenError(tok)
+	}
+	return &Expression{
+		Source:  source,
+		root:    root,
+		columns: p.columns,
+	}, nil
+}
+
+// Columns returns the names of the columns used in the expression.
+func (e *Expression) Columns() []string {
+	return e.columns
+}
+
+// Check type checks the expression, given a function returning the Go type of the values of a column by its name.
+func (e *Expression) Check(columnType func(name string) (string, error)) error {
+	t, err := e.root.check(columnType)
+	if err != nil {
+		return err
+	}
+	e.outputType = t
+	return nil
+}
+
+// OutputType returns the Go type of the result of the expression, or an empty string if it is not type checked.
+func (e *Expression) OutputType() string {
+	return string(e.outputType)
+}
+
+// Evaluate evaluates the expression, given a function returning the value of a column by its name.
+func (e *Expression) Evaluate(value func(name string) (interface{}, error)) (interface{}, error) {
+	if e.outputType == "" {
+		return nil, ExpressionError(fmt.Sprintf("%s is not type checked", e.Source))
+	}
+	return e.root.eval(value)
+}
+
+// Formula returns a formula evaluating the expression over the columns of a specific schema.
+func (e *Expression) Formula(schemaName string) Formula {
+	prefixPattern := schemaName + "."
+	return func(faker *gofakeit.Faker, seqNum int, args ...string) (interface{}, error) {
+		return e.Evaluate(func(name string) (interface{}, error) {
+			return GetFakeData(prefixPattern+name, seqNum)
+		})
+	}
+}
+
+// toExprType maps the Go type of the values of a column to the type used in expressions.
+func toExprType(outputType string) (exprType, bool) {
+	switch outputType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		return exprInt, true
+	case "float32", "float64":
+		return exprFloat, true
+	case "string":
+		return exprString, true
+	case "bool":
+		return exprBool, true
+	case "time.Time":
+		return exprTime, true
+	}
+	return "", false
+}
+
+// toExprValue converts a value of a column to the Go type used in expressions for the type.
+func toExprValue(t exprType, v interface{}) (interface{}, bool) {
+	switch t {
+	case exprInt:
+		if v, ok := toColumnValue(ColumnTypeLong, v); ok {
+			return int(v.(int64)), true
+		}
+	case exprFloat:
+		if v, ok := toColumnValue(ColumnTypeDouble, v); ok {
+			return v, true
+		}
+	case exprString:
+		if v, ok := v.(string); ok {
+			return v, true
+		}
+	case exprBool:
+		if v, ok := v.(bool); ok {
+			return v, true
+		}
+	case exprTime:
+		if v, ok := v.(time.Time); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// isNumber checks whether the type is a numeric type.
+func isNumber(t exprType) bool {
+	return t == exprInt || t == exprFloat
+}
+
+// toFloat converts a numeric value to float64.
+func toFloat(v interface{}) float64 {
+	if i, ok := v.(int); ok {
+		return float64(i)
+	}
+	return v.(float64)
+}
+
+// exprTokenKind represents the kind of a token in the source code of an expression.
+type exprTokenKind int
+
+const (
+	exprTokenEOF exprTokenKind = iota
+	exprTokenInt
+	exprTokenFloat
+	exprTokenString
+	exprTokenIdent
+	exprTokenColumn
+	exprTokenOperator
+)
+
+// exprToken is a token in the source code of an expression.
+type exprToken struct {
+	kind exprTokenKind
+	// Text of operators and names of identifiers and columns
+	text string
+	// Value of literals
+	value interface{}
+	// Position of the token in the source code, in bytes
+	pos int
+}
+
+// exprOperators lists the operators, with the longer ones first so that they are matched first.
+var exprOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "+", "-", "*", "/", "%", "<", ">", "!", "?", ":", "(", ")", ","}
+
+// tokenizeExpression splits the source code of an expression into tokens.
+func tokenizeExpression(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	pos := 0
+	for {
+		for pos < len(source) && (source[pos] == ' ' || source[pos] == '\t' || source[pos] == '\n' || source[pos] == '\r') {
+			pos++
+		}
+		if pos == len(source) {
+			return append(tokens, exprToken{kind: exprTokenEOF, pos: pos}), nil
+		}
+
+		start := pos
+		c := source[pos]
+		switch {
+		case c >= '0' && c <= '9' || c == '.' && pos+1 < len(source) && source[pos+1] >= '0' && source[pos+1] <= '9':
+			isFloat := false
+			for pos < len(source) && (source[pos] >= '0' && source[pos] <= '9' || source[pos] == '.') {
+				isFloat = isFloat || source[pos] == '.'
+				pos++
+			}
+			if pos < len(source) && (source[pos] == 'e' || source[pos] == 'E') {
+				isFloat = true
+				pos++
+				if pos < len(source) && (source[pos] == '+' || source[pos] == '-') {
+					pos++
+				}
+				for pos < len(source) && source[pos] >= '0' && source[pos] <= '9' {
+					pos++
+				}
+			}
+			text := source[start:pos]
+			if isFloat {
+				v, err := strconv.ParseFloat(text, 64)
+				if err != nil {
+					return nil, ExpressionError(fmt.Sprintf("invalid number %s at position %d", text, start))
+				}
+				tokens = append(tokens, exprToken{kind: exprTokenFloat, text: text, value: v, pos: start})
+			} else {
+				v, err := strconv.Atoi(text)
+				if err != nil {
+					return nil, ExpressionError(fmt.Sprintf("invalid number %s at position %d", text, start))
+				}
+				tokens = append(tokens, exprToken{kind: exprTokenInt, text: text, value: v, pos: start})
+			}
+
+		case c == '"' || c == '\'':
+			var sb strings.Builder
+			pos++
+			for {
+				if pos >= len(source) {
+					return nil, ExpressionError(fmt.Sprintf("unterminated string at position %d", start))
+				}
+				if source[pos] == c {
+					pos++
+					break
+				}
+				if source[pos] == '\\' && pos+1 < len(source) {
+					pos++
+					switch source[pos] {
+					case 'n':
+						sb.WriteByte('\n')
+					case 't':
+						sb.WriteByte('\t')
+					default:
+						sb.WriteByte(source[pos])
+					}
+					pos++
+					continue
+				}
+				sb.WriteByte(source[pos])
+				pos++
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenString, text: source[start:pos], value: sb.String(), pos: start})
+
+		case c == '`':
+			// Quoted column names may contain any character but backticks
+			end := strings.IndexByte(source[pos+1:], '`')
+			if end < 0 {
+				return nil, ExpressionError(fmt.Sprintf("unterminated column name at position %d", start))
+			}
+			pos += end + 2
+			tokens = append(tokens, exprToken{kind: exprTokenColumn, text: source[start+1 : pos-1], pos: start})
+
+		case c == '_' || c < utf8.RuneSelf && unicode.IsLetter(rune(c)):
+			for pos < len(source) && (source[pos] == '_' || source[pos] < utf8.RuneSelf &&
+				(unicode.IsLetter(rune(source[pos])) || unicode.IsDigit(rune(source[pos])))) {
+				pos++
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenIdent, text: source[start:pos], pos: start})
+
+		default:
+			matched := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(source[pos:], op) {
+					tokens = append(tokens, exprToken{kind: exprTokenOperator, text: op, pos: start})
+					pos += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				r, _ := utf8.DecodeRuneInString(source[pos:])
+				return nil, ExpressionError(fmt.Sprintf("unexpected character %q at position %d", r, start))
+			}
+		}
+	}
+}
+
+// exprBinaryPrecedences maps binary operators to their precedences, higher binding tighter.
+var exprBinaryPrecedences = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+// exprParser is a recursive descent parser of expressions.
+type exprParser struct {
+	tokens  []exprToken
+	pos     int
+	columns []string
+}
+
+// peek returns the current token without consuming it.
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the current token.
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != exprTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isOperator checks whether the current token is the given operator.
+func (p *exprParser) isOperator(op string) bool {
+	tok := p.peek()
+	return tok.kind == exprTokenOperator && tok.text == op
+}
+
+// expect consumes the current token if it is the given operator, or returns an error otherwise.
+func (p *exprParser) expect(op string) error {
+	if !p.isOperator(op) {
+		return unexpectedTokenError(p.peek())
+	}
+	p.next()
+	return nil
+}
+
+// parseTernary parses `cond ? x : y`, which has the lowest precedence and is right-associative.
+func (p *exprParser) parseTernary() (exprNode, error) {
+	cond, err := p.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOperator("?") {
+		return cond, nil
+	}
+	p.next()
+	x, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	y, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	return &exprTernaryNode{cond: cond, x: x, y: y}, nil
+}
+
+// parseBinary parses left-associative binary operations with at least the given precedence.
+func (p *exprParser) parseBinary(minPrecedence int) (exprNode, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		precedence, ok := exprBinaryPrecedences[tok.text]
+		if tok.kind != exprTokenOperator || !ok || precedence < minPrecedence {
+			return x, nil
+		}
+		p.next()
+		y, err := p.parseBinary(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		x = &exprBinaryNode{op: tok.text, x: x, y: y}
+	}
+}
+
+// parseUnary parses negations and logical NOTs.
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.isOperator("-") || p.isOperator("!") {
+		op := p.next().text
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprUnaryNode{op: op, x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses literals, columns, function calls and parenthesized expressions.
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case exprTokenInt:
+		return &exprLiteralNode{value: tok.value, t: exprInt}, nil
+	case exprTokenFloat:
+		return &exprLiteralNode{value: tok.value, t: exprFloat}, nil
+	case exprTokenString:
+		return &exprLiteralNode{value: tok.value, t: exprString}, nil
+	case exprTokenColumn:
+		return p.column(tok.text), nil
+	case exprTokenIdent:
+		switch {
+		case tok.text == "true" || tok.text == "false":
+			return &exprLiteralNode{value: tok.text == "true", t: exprBool}, nil
+		case p.isOperator("("):
+			return p.parseCall(tok)
+		default:
+			return p.column(tok.text), nil
+		}
+	case exprTokenOperator:
+		if tok.text == "(" {
+			x, err := p.parseTernary()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		}
+	}
+	return nil, unexpectedTokenError(tok)
+}
+
+// parseCall parses the arguments of a call to the function named by the token.
+func (p *exprParser) parseCall(tok exprToken) (exprNode, error) {
+	fn, ok := exprFuncs[tok.text]
+	if !ok {
+		return nil, ExpressionError(fmt.Sprintf("unknown function %s at position %d", tok.text, tok.pos))
+	}
+	p.next()
+	var args []exprNode
+	for !p.isOperator(")") {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.next()
+	return &exprCallNode{name: tok.text, fn: fn, args: args}, nil
+}
+
+// column creates a node for a column and records its name.
+func (p *exprParser) column(name string) exprNode {
+	found := false
+	for _, column := range p.columns {
+		found = found || column == name
+	}
+	if !found {
+		p.columns = append(p.columns, name)
+	}
+	return &exprColumnNode{name: name}
+}
+
+// unexpectedTokenError creates an error for a token not expected by the parser.
+func unexpectedTokenError(tok exprToken) error {
+	if tok.kind == exprTokenEOF {
+		return ExpressionError("unexpected end of expression")
+	}
+	return ExpressionError(fmt.Sprintf("unexpected %s at position %d", tok.text, tok.pos))
+}
+
+// exprNode is a node in the syntax tree of an expression. Nodes record their types when type checked, and can only
+// be evaluated afterward.
+type exprNode interface {
+	check(columnType func(name string) (string, error)) (exprType, error)
+	eval(value func(name string) (interface{}, error)) (interface{}, error)
+}
+
+// exprLiteralNode is a literal value.
+type exprLiteralNode struct {
+	value interface{}
+	t     exprType
+}
+
+func (n *exprLiteralNode) check(columnType func(name string) (string, error)) (exprType, error) {
+	return n.t, nil
+}
+
+func (n *exprLiteralNode) eval(value func(name string) (interface{}, error)) (interface{}, error) {
+	return n.value, nil
+}
+
+// exprColumnNode is the value of a column in the same record.
+type exprColumnNode struct {
+	name string
+	t    exprType
+}
+
+func (n *exprColumnNode) check(columnType func(name string) (string, error)) (exprType, error) {
+	outputType, err := columnType(n.name)
+	if err != nil {
+		return "", err
+	}
+	t, ok := toExprType(outputType)
+	if !ok {
+		return "", ExpressionError(fmt.Sprintf("column %s has type %s, which is not supported", n.name, outputType))
+	}
+	n.t = t
+	return t, nil
+}
+
+func (n *exprColumnNode) eval(value func(name string) (interface{}, error)) (interface{}, error) {
+	v, err := value(n.name)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := toExprValue(n.t, v); ok {
+		return v, nil
+	}
+	return nil, TypeError(n.name)
+}
+
+// exprUnaryNode is a negation or a logical NOT.
+type exprUnaryNode struct {
+	op string
+	x  exprNode
+	t  exprType
+}
+
+func (n *exprUnaryNode) check(columnType func(name string) (string, error)) (exprType, error) {
+	t, err := n.x.check(columnType)
+	if err != nil {
+		return "", err
+	}
+	if n.op == "-" && isNumber(t) || n.op == "!" && t == exprBool {
+		n.t = t
+		return t, nil
+	}
+	return "", ExpressionError(fmt.Sprintf("operator %s not defined on %s", n.op, t))
+}
+
+func (n *exprUnaryNode) eval(value func(name string) (interface{}, error)) (interface{}, error) {
+	x, err := n.x.eval(value)
+	if err != nil {
+		return nil, err
+	}
+	switch x := x.(type) {
+	case int:
+		return -x, nil
+	case float64:
+		return -x, nil
+	case bool:
+		return !x, nil
+	}
+	return nil, TypeError(n.op)
+}
+
+// exprBinaryNode is an arithmetic, comparison or logical operation.
+type exprBinaryNode struct {
+	op string
+	x  exprNode
+	y  exprNode
+	// Type the operands are converted to before the operation
+	operandType exprType
+	t           exprType
+}
+
+func (n *exprBinaryNode) check(columnType func(name string) (string, error)) (exprType, error) {
+	xt, err := n.x.check(columnType)
+	if err != nil {
+		return "", err
+	}
+	yt, err := n.y.check(columnType)
+	if err != nil {
+		return "", err
+	}
+
+	// Integers are promoted to floats when mixed with them
+	operandType := xt
+	if isNumber(xt) && isNumber(yt) && xt != yt {
+		operandType = exprFloat
+	} else if xt != yt {
+		operandType = ""
+	}
+
+	ok := false
+	switch n.op {
+	case "+":
+		ok = isNumber(operandType) || operandType == exprString
+		n.t = operandType
+	case "-", "*", "/":
+		ok = isNumber(operandType)
+		n.t = operandType
+	case "%":
+		ok = operandType == exprInt
+		n.t = operandType
+	case "<", "<=", ">", ">=":
+		ok = isNumber(operandType) || operandType == exprString || operandType == exprTime
+		n.t = exprBool
+	case "==", "!=":
+		ok = operandType != ""
+		n.t = exprBool
+	case "&&", "||":
+		ok = operandType == exprBool
+		n.t = exprBool
+	}
+	if !ok {
+		return "", ExpressionError(fmt.Sprintf("operator %s not defined on %s and %s", n.op, xt, yt))
+	}
+	n.operandType = operandType
+	return n.t, nil
+}
+
+func (n *exprBinaryNode) eval(value func(name string) (interface{}, error)) (interface{}, error) {
+	x, err := n.x.eval(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// Logical operators short-circuit
+	if n.op == "&&" || n.op == "||" {
+		if x.(bool) == (n.op == "||") {
+			return x, nil
+		}
+		return n.y.eval(value)
+	}
+
+	y, err := n.y.eval(value)
+	if err != nil {
+		return nil, err
+	}
+	if n.operandType == exprFloat {
+		x, y = toFloat(x), toFloat(y)
+	}
+
+	switch n.op {
+	case "+", "-", "*", "/", "%":
+		return evalArithmetic(n.op, x, y)
+	case "==":
+		return compareValues(x, y) == 0, nil
+	case "!=":
+		return compareValues(x, y) != 0, nil
+	case "<":
+		return compareValues(x, y) < 0, nil
+	case "<=":
+		return compareValues(x, y) <= 0, nil
+	case ">":
+		return compareValues(x, y) > 0, nil
+	case ">=":
+		return compareValues(x, y) >= 0, nil
+	}
+	return nil, TypeError(n.op)
+}
+
+// evalArithmetic applies an arithmetic operator to two values of the same type.
+func evalArithmetic(op string, x, y interface{}) (interface{}, error) {
+	switch x := x.(type) {
+	case int:
+		y := y.(int)
+		switch op {
+		case "+":
+			return x + y, nil
+		case "-":
+			return x - y, nil
+		case "*":
+			return x * y, nil
+		case "/", "%":
+			if y == 0 {
+				return nil, ExpressionError("division by zero")
+			}
+			if op == "/" {
+				return x / y, nil
+			}
+			return x % y, nil
+		}
+	case float64:
+		y := y.(float64)
+		switch op {
+		case "+":
+			return x + y, nil
+		case "-":
+			return x - y, nil
+		case "*":
+			return x * y, nil
+		case "/":
+			if y == 0 {
+				return nil, ExpressionError("division by zero")
+			}
+			return x / y, nil
+		}
+	case string:
+		return x + y.(string), nil
+	}
+	return nil, TypeError(op)
+}
+
+// compareValues compares two values of the same type, returning -1, 0 or 1. Booleans are only compared for
+// equality.
+func compareValues(x, y interface{}) int {
+	switch x := x.(type) {
+	case int:
+		return compareOrdered(x, y.(int))
+	case float64:
+		return compareOrdered(x, y.(float64))
+	case string:
+		return compareOrdered(x, y.(string))
+	case time.Time:
+		return x.Compare(y.(time.Time))
+	case bool:
+		if x == y.(bool) {
+			return 0
+		}
+	}
+	return 1
+}
+
+// compareOrdered compares two ordered values, returning -1, 0 or 1.
+func compareOrdered[T int | float64 | string](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// exprTernaryNode is a conditional value.
+type exprTernaryNode struct {
+	cond exprNode
+	x    exprNode
+	y    exprNode
+	t    exprType
+}
+
+func (n *exprTernaryNode) check(columnType func(name string) (string, error)) (exprType, error) {
+	ct, err := n.cond.check(columnType)
+	if err != nil {
+		return "", err
+	}
+	if ct != exprBool {
+		return "", ExpressionError(fmt.Sprintf("condition of ternary must be bool, not %s", ct))
+	}
+	xt, err := n.x.check(columnType)
+	if err != nil {
+		return "", err
+	}
+	yt, err := n.y.check(columnType)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case xt == yt:
+		n.t = xt
+	case isNumber(xt) && isNumber(yt):
+		n.t = exprFloat
+	default:
+		return "", ExpressionError(fmt.Sprintf("branches of ternary have different types %s and %s", xt, yt))
+	}
+	return n.t, nil
+}
+
+func (n *exprTernaryNode) eval(value func(name string) (interface{}, error)) (interface{}, error) {
+	cond, err := n.cond.eval(value)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if cond.(bool) {
+		v, err = n.x.eval(value)
+	} else {
+		v, err = n.y.eval(value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if n.t == exprFloat {
+		v = toFloat(v)
+	}
+	return v, nil
+}
+
+// exprCallNode is a call to a function.
+type exprCallNode struct {
+	name string
+	fn   exprFunc
+	args []exprNode
+	t    exprType
+}
+
+func (n *exprCallNode) check(columnType func(name string) (string, error)) (exprType, error) {
+	argTypes := make([]exprType, len(n.args))
+	argTypeNames := make([]string, len(n.args))
+	for i, arg := range n.args {
+		t, err := arg.check(columnType)
+		if err != nil {
+			return "", err
+		}
+		argTypes[i] = t
+		argTypeNames[i] = string(t)
+	}
+	t, ok := n.fn.check(argTypes)
+	if !ok {
+		return "", ExpressionError(fmt.Sprintf("invalid arguments to %s(%s)", n.name, strings.Join(argTypeNames, ", ")))
+	}
+	n.t = t
+	return t, nil
+}
+
+func (n *exprCallNode) eval(value func(name string) (interface{}, error)) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(value)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	return n.fn.eval(args)
+}
+
+// exprFunc is a function available in expressions.
+type exprFunc struct {
+	// Returns the type of the result for the types of the arguments, or false if they are not accepted
+	check func(args []exprType) (exprType, bool)
+	// Evaluates the function on arguments of the accepted types
+	eval func(args []interface{}) (interface{}, error)
+}
+
+// exprFuncs maps function names to the functions available in expressions.
+var exprFuncs = map[string]exprFunc{
+	// Numbers
+	"abs": {numericSignature(1), func(args []interface{}) (interface{}, error) {
+		if x, ok := args[0].(int); ok {
+			if x < 0 {
+				return -x, nil
+			}
+			return x, nil
+		}
+		return math.Abs(args[0].(float64)), nil
+	}},
+	"min": {numericSignature(2), func(args []interface{}) (interface{}, error) {
+		if x, ok := args[0].(int); ok {
+			if y, ok := args[1].(int); ok {
+				return min(x, y), nil
+			}
+		}
+		return math.Min(toFloat(args[0]), toFloat(args[1])), nil
+	}},
+	"max": {numericSignature(2), func(args []interface{}) (interface{}, error) {
+		if x, ok := args[0].(int); ok {
+			if y, ok := args[1].(int); ok {
+				return max(x, y), nil
+			}
+		}
+		return math.Max(toFloat(args[0]), toFloat(args[1])), nil
+	}},
+	"round": {signature(exprFloat, exprNumber), func(args []interface{}) (interface{}, error) {
+		return math.Round(toFloat(args[0])), nil
+	}},
+	"floor": {signature(exprFloat, exprNumber), func(args []interface{}) (interface{}, error) {
+		return math.Floor(toFloat(args[0])), nil
+	}},
+	"ceil": {signature(exprFloat, exprNumber), func(args []interface{}) (interface{}, error) {
+		return math.Ceil(toFloat(args[0])), nil
+	}},
+
+	// Conversions
+	"int": {conversionSignature(exprInt), func(args []interface{}) (interface{}, error) {
+		switch x := args[0].(type) {
+		case float64:
+			return int(x), nil
+		case string:
+			v, err := strconv.Atoi(strings.TrimSpace(x))
+			if err != nil {
+				return nil, ExpressionError(fmt.Sprintf("cannot convert %q to int", x))
+			}
+			return v, nil
+		}
+		return args[0], nil
+	}},
+	"float": {conversionSignature(exprFloat), func(args []interface{}) (interface{}, error) {
+		if x, ok := args[0].(string); ok {
+			v, err := strconv.ParseFloat(strings.TrimSpace(x), 64)
+			if err != nil {
+				return nil, ExpressionError(fmt.Sprintf("cannot convert %q to float", x))
+			}
+			return v, nil
+		}
+		return toFloat(args[0]), nil
+	}},
+	"string": {signature(exprString, exprAny), func(args []interface{}) (interface{}, error) {
+		// Formatted in the same way as in CSV files
+		return fmt.Sprint(args[0]), nil
+	}},
+
+	// Strings
+	"len": {signature(exprInt, exprString), func(args []interface{}) (interface{}, error) {
+		return utf8.RuneCountInString(args[0].(string)), nil
+	}},
+	"upper": {signature(exprString, exprString), func(args []interface{}) (interface{}, error) {
+		return strings.ToUpper(args[0].(string)), nil
+	}},
+	"lower": {signature(exprString, exprString), func(args []interface{}) (interface{}, error) {
+		return strings.ToLower(args[0].(string)), nil
+	}},
+	"trim": {signature(exprString, exprString), func(args []interface{}) (interface{}, error) {
+		return strings.TrimSpace(args[0].(string)), nil
+	}},
+	"substr": {signature(exprString, exprString, exprInt, exprInt), func(args []interface{}) (interface{}, error) {
+		// Indexes are in characters and clamped to the string
+		runes := []rune(args[0].(string))
+		start := min(max(args[1].(int), 0), len(runes))
+		end := min(max(args[2].(int), start), len(runes))
+		return string(runes[start:end]), nil
+	}},
+	"contains": {signature(exprBool, exprString, exprString), func(args []interface{}) (interface{}, error) {
+		return strings.Contains(args[0].(string), args[1].(string)), nil
+	}},
+	"startsWith": {signature(exprBool, exprString, exprString), func(args []interface{}) (interface{}, error) {
+		return strings.HasPrefix(args[0].(string), args[1].(string)), nil
+	}},
+	"endsWith": {signature(exprBool, exprString, exprString), func(args []interface{}) (interface{}, error) {
+		return strings.HasSuffix(args[0].(string), args[1].(string)), nil
+	}},
+	"replace": {signature(exprString, exprString, exprString, exprString), func(args []interface{}) (interface{}, error) {
+		return strings.ReplaceAll(args[0].(string), args[1].(string), args[2].(string)), nil
+	}},
+
+	// Dates
+	"date": {signature(exprTime, exprString), func(args []interface{}) (interface{}, error) {
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, args[0].(string)); err == nil {
+				return t, nil
+			}
+		}
+		return nil, ExpressionError(fmt.Sprintf("cannot convert %q to date", args[0]))
+	}},
+	"formatDate": {signature(exprString, exprTime, exprString), func(args []interface{}) (interface{}, error) {
+		return args[0].(time.Time).Format(args[1].(string)), nil
+	}},
+	"unixMilli": {signature(exprInt, exprTime), func(args []interface{}) (interface{}, error) {
+		return int(args[0].(time.Time).UnixMilli()), nil
+	}},
+	"fromUnixMilli": {signature(exprTime, exprInt), func(args []interface{}) (interface{}, error) {
+		return time.UnixMilli(int64(args[0].(int))).UTC(), nil
+	}},
+	"addDays": {signature(exprTime, exprTime, exprInt), func(args []interface{}) (interface{}, error) {
+		return args[0].(time.Time).AddDate(0, 0, args[1].(int)), nil
+	}},
+	"addHours": {signature(exprTime, exprTime, exprInt), func(args []interface{}) (interface{}, error) {
+		return args[0].(time.Time).Add(time.Duration(args[1].(int)) * time.Hour), nil
+	}},
+	"addMinutes": {signature(exprTime, exprTime, exprInt), func(args []interface{}) (interface{}, error) {
+		return args[0].(time.Time).Add(time.Duration(args[1].(int)) * time.Minute), nil
+	}},
+	"addSeconds": {signature(exprTime, exprTime, exprInt), func(args []interface{}) (interface{}, error) {
+		return args[0].(time.Time).Add(time.Duration(args[1].(int)) * time.Second), nil
+	}},
+	"diffMillis": {signature(exprInt, exprTime, exprTime), func(args []interface{}) (interface{}, error) {
+		return int(args[0].(time.Time).Sub(args[1].(time.Time)).Milliseconds()), nil
+	}},
+	"year": {signature(exprInt, exprTime), func(args []interface{}) (interface{}, error) {
+		return args[0].(time.Time).Year(), nil
+	}},
+	"month": {signature(exprInt, exprTime), func(args []interface{}) (interface{}, error) {
+		return int(args[0].(time.Time).Month()), nil
+	}},
+	"day": {signature(exprInt, exprTime), func(args []interface{}) (interface{}, error) {
+		return args[0].(time.Time).Day(), nil
+	}},
+	"hour": {signature(exprInt, exprTime), func(args []interface{}) (interface{}, error) {
+		return args[0].(time.Time).Hour(), nil
+	}},
+}
+
+// signature creates a type check for functions with fixed parameter types. exprNumber accepts integers and floats,
+// and exprAny accepts any type.
+func signature(result exprType, params ...exprType) func(args []exprType) (exprType, bool) {
+	return func(args []exprType) (exprType, bool) {
+		if len(args) != len(params) {
+			return "", false
+		}
+		for i, param := range params {
+			if param != args[i] && param != exprAny && !(param == exprNumber && isNumber(args[i])) {
+				return "", false
+			}
+		}
+		return result, true
+	}
+}
+
+// numericSignature creates a type check for functions with numeric parameters, whose result is a float if any
+// argument is a float, or an integer otherwise.
+func numericSignature(numParams int) func(args []exprType) (exprType, bool) {
+	return func(args []exprType) (exprType, bool) {
+		if len(args) != numParams {
+			return "", false
+		}
+		result := exprInt
+		for _, arg := range args {
+			if !isNumber(arg) {
+				return "", false
+			}
+			if arg == exprFloat {
+				result = exprFloat
+			}
+		}
+		return result, true
+	}
+}
+
+// conversionSignature creates a type check for functions converting a number or a string to a numeric type.
+func conversionSignature(result exprType) func(args []exprType) (exprType, bool) {
+	return func(args []exprType) (exprType, bool) {
+		if len(args) != 1 || !isNumber(args[0]) && args[0] != exprString {
+			return "", false
+		}
+		return result, true
+	}
+}
@@ -0,0 +1,314 @@
+package datagen
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testColumn is a column available to the expressions under test.
+type testColumn struct {
+	outputType string
+	value      interface{}
+}
+
+var testDate = time.Date(2024, time.March, 5, 10, 20, 30, 0, time.UTC)
+
+var testColumns = map[string]testColumn{
+	"a":          {"int", 7},
+	"b":          {"int32", int32(2)},
+	"z":          {"int", 0},
+	"x":          {"float64", 1.5},
+	"s":          {"string", " Hello, World "},
+	"flag":       {"bool", true},
+	"d":          {"time.Time", testDate},
+	"unit price": {"float32", float32(2.5)},
+	"tags":       {"[]string", []string{"a"}},
+	"broken":     {"int", "not an int"},
+}
+
+func testColumnType(name string) (string, error) {
+	column, ok := testColumns[name]
+	if !ok {
+		return "", ReferenceError(name)
+	}
+	return column.outputType, nil
+}
+
+func testColumnValue(name string) (interface{}, error) {
+	column, ok := testColumns[name]
+	if !ok {
+		return nil, ReferenceError(name)
+	}
+	return column.value, nil
+}
+
+// evaluateExpression parses, type checks and evaluates an expression over the test columns.
+func evaluateExpression(source string) (interface{}, *Expression, error) {
+	e, err := ParseExpression(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := e.Check(testColumnType); err != nil {
+		return nil, e, err
+	}
+	v, err := e.Evaluate(testColumnValue)
+	return v, e, err
+}
+
+func TestExpressionEvaluate(t *testing.T) {
+	tests := []struct {
+		source string
+		want   interface{}
+	}{
+		// Precedence and associativity
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"100 / 10 / 5", 2},
+		{"2 * 3 % 4", 2},
+		{"-2 * 3", -6},
+		{"- -2", 2},
+		{"1 + 2 < 4", true},
+		{"1 < 2 == true", true},
+		{"true || false && false", true},
+		{"!false && false", false},
+		{"1 == 1 && 2 != 3", true},
+		{"a % b", 1},
+		{"a / b", 3},
+
+		// Promotion of integers to floats
+		{"a + x", 8.5},
+		{"a / 2.0", 3.5},
+		{"a * 1.0", 7.0},
+		{".5 * 2", 1.0},
+		{"1e3 + 1", 1001.0},
+		{"1 == 1.0", true},
+		{"b < x", false},
+		{"-x", -1.5},
+		{"`unit price` * b", 5.0},
+
+		// Ternaries
+		{"a > b ? 'big' : 'small'", "big"},
+		{"a < b ? 1 : 2.5", 2.5},
+		{"a > b ? 1 : 2.5", 1.0},
+		{"false ? 1 : true ? 2 : 3", 2},
+		{"true ? 1 : false ? 2 : 3", 1},
+		{"flag ? a : b", 7},
+
+		// Short circuits skip the evaluation of the right operand
+		{"z == 0 || a / z > 0", true},
+		{"z != 0 && a / z > 0", false},
+
+		// Strings
+		{`'a' + "b"`, "ab"},
+		{`'it\'s\t' + "\"x\"\n"`, "it's\t\"x\"\n"},
+		{"'abc' < 'abd'", true},
+		{"len(s)", 14},
+		{"len('héllo')", 5},
+		{"upper('abc')", "ABC"},
+		{"lower('ABC')", "abc"},
+		{"trim(s)", "Hello, World"},
+		{"substr('hello', 1, 3)", "el"},
+		{"substr('héllo', 1, 2)", "é"},
+		{"substr('hello', -2, 10)", "hello"},
+		{"substr('hello', 4, 2)", ""},
+		{"contains(s, 'World')", true},
+		{"contains(s, 'world')", false},
+		{"startsWith('hello', 'he')", true},
+		{"startsWith('hello', 'lo')", false},
+		{"endsWith('hello', 'lo')", true},
+		{"endsWith('hello', 'he')", false},
+		{"replace('a-b-c', '-', '+')", "a+b+c"},
+
+		// Numbers
+		{"abs(-3)", 3},
+		{"abs(3)", 3},
+		{"abs(-1.5)", 1.5},
+		{"min(a, b)", 2},
+		{"min(a, x)", 1.5},
+		{"max(a, b)", 7},
+		{"max(a, x)", 7.0},
+		{"round(2.5)", 3.0},
+		{"round(a)", 7.0},
+		{"floor(x)", 1.0},
+		{"ceil(x)", 2.0},
+
+		// Conversions
+		{"int('42')", 42},
+		{"int(' 3 ')", 3},
+		{"int(x)", 1},
+		{"int(-x)", -1},
+		{"int(a)", 7},
+		{"float('2.5')", 2.5},
+		{"float(a)", 7.0},
+		{"string(a)", "7"},
+		{"string(x)", "1.5"},
+		{"string(flag)", "true"},
+
+		// Dates
+		{"date('2024-03-05T10:20:30Z') == d", true},
+		{"date('2024-03-05 10:20:30') == d", true},
+		{"date('2024-03-05') < d", true},
+		{"d < addDays(d, 1)", true},
+		{"formatDate(d, '2006-01-02 15:04:05')", "2024-03-05 10:20:30"},
+		{"unixMilli(date('1970-01-02'))", 86400000},
+		{"fromUnixMilli(0) == date('1970-01-01')", true},
+		{"formatDate(fromUnixMilli(86400000 + 1000), '2006-01-02 15:04:05')", "1970-01-02 00:00:01"},
+		{"formatDate(addDays(d, 30), '2006-01-02')", "2024-04-04"},
+		{"formatDate(addDays(d, -5), '2006-01-02')", "2024-02-29"},
+		{"formatDate(addHours(d, 14), '2006-01-02 15:04:05')", "2024-03-06 00:20:30"},
+		{"formatDate(addMinutes(d, -21), '15:04:05')", "09:59:30"},
+		{"formatDate(addSeconds(d, 30), '15:04:05')", "10:21:00"},
+		{"diffMillis(addSeconds(d, 2), d)", 2000},
+		{"diffMillis(d, addSeconds(d, 2))", -2000},
+		{"year(d)", 2024},
+		{"month(d)", 3},
+		{"day(d)", 5},
+		{"hour(d)", 10},
+		{"date('2024-03-05')", time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got, e, err := evaluateExpression(tt.source)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+			if gotType := fmt.Sprintf("%T", got); gotType != e.OutputType() {
+				t.Errorf("got value of type %s, but output type is %s", gotType, e.OutputType())
+			}
+		})
+	}
+}
+
+func TestExpressionEvaluateError(t *testing.T) {
+	tests := []struct {
+		source string
+		want   error
+	}{
+		{"a / z", ExpressionError("division by zero")},
+		{"a % z", ExpressionError("division by zero")},
+		{"x / 0.0", ExpressionError("division by zero")},
+		{"x / z", ExpressionError("division by zero")},
+		{"int('abc')", ExpressionError(`cannot convert "abc" to int`)},
+		{"int('1.5')", ExpressionError(`cannot convert "1.5" to int`)},
+		{"float('x')", ExpressionError(`cannot convert "x" to float`)},
+		{"date('not a date')", ExpressionError(`cannot convert "not a date" to date`)},
+		{"broken + 1", TypeError("broken")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got, _, err := evaluateExpression(tt.source)
+			if err != tt.want {
+				t.Errorf("got value %#v and error %v, want error %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpressionCheckError(t *testing.T) {
+	tests := []struct {
+		source string
+		want   error
+	}{
+		// Unknown identifiers are columns, which are resolved when type checked
+		{"missing + 1", ReferenceError("missing")},
+		{"a + `no such column`", ReferenceError("no such column")},
+		{"tags", ExpressionError("column tags has type []string, which is not supported")},
+
+		{"1 + 'a'", ExpressionError("operator + not defined on int and string")},
+		{"'a' - 'b'", ExpressionError("operator - not defined on string and string")},
+		{"1.5 % 2", ExpressionError("operator % not defined on float64 and int")},
+		{"x % x", ExpressionError("operator % not defined on float64 and float64")},
+		{"true < false", ExpressionError("operator < not defined on bool and bool")},
+		{"a && b", ExpressionError("operator && not defined on int and int")},
+		{"d + 1", ExpressionError("operator + not defined on time.Time and int")},
+		{"d == 'x'", ExpressionError("operator == not defined on time.Time and string")},
+		{"!1", ExpressionError("operator ! not defined on int")},
+		{"-'a'", ExpressionError("operator - not defined on string")},
+		{"1 ? 2 : 3", ExpressionError("condition of ternary must be bool, not int")},
+		{"flag ? 1 : 'a'", ExpressionError("branches of ternary have different types int and string")},
+		{"len(1)", ExpressionError("invalid arguments to len(int)")},
+		{"upper()", ExpressionError("invalid arguments to upper()")},
+		{"substr('a', 1)", ExpressionError("invalid arguments to substr(string, int)")},
+		{"substr('a', 1, 2.0)", ExpressionError("invalid arguments to substr(string, int, float64)")},
+		{"abs('a')", ExpressionError("invalid arguments to abs(string)")},
+		{"year('2024-01-01')", ExpressionError("invalid arguments to year(string)")},
+		{"addDays(d, 1.5)", ExpressionError("invalid arguments to addDays(time.Time, float64)")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			e, err := ParseExpression(tt.source)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if err := e.Check(testColumnType); err != tt.want {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExpressionError(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"1 +", "unexpected end of expression"},
+		{"(1 + 2", "unexpected end of expression"},
+		{"a ? b", "unexpected end of expression"},
+		{"", "unexpected end of expression"},
+		{"1 + * 2", "unexpected * at position 4"},
+		{"1 2", "unexpected 2 at position 2"},
+		{")", "unexpected ) at position 0"},
+		{"a ? b , c", "unexpected , at position 6"},
+		{"abs(1,, 2)", "unexpected , at position 6"},
+		{"max(1 2)", "unexpected 2 at position 6"},
+		{"'abc", "unterminated string at position 0"},
+		{"a + `col", "unterminated column name at position 4"},
+		{"1 # 2", "unexpected character '#' at position 2"},
+		{"a == é", "unexpected character 'é' at position 5"},
+		{"1.2.3", "invalid number 1.2.3 at position 0"},
+		{"2 * 1e", "invalid number 1e at position 4"},
+		{"foo(1)", "unknown function foo at position 0"},
+		{"1 + bar()", "unknown function bar at position 4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			e, err := ParseExpression(tt.source)
+			if want := ExpressionError(tt.want); err != want {
+				t.Errorf("got expression %v and error %v, want error %v", e, err, want)
+			}
+		})
+	}
+}
+
+func TestExpressionColumns(t *testing.T) {
+	e, err := ParseExpression("a + b * a + `unit price` + len(s) + (flag ? 1 : b)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"a", "b", "unit price", "s", "flag"}
+	if got := e.Columns(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got columns %v, want %v", got, want)
+	}
+}
+
+func TestExpressionEvaluateUnchecked(t *testing.T) {
+	e, err := ParseExpression("1 + 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var exprErr ExpressionError
+	if _, err := e.Evaluate(testColumnValue); !errors.As(err, &exprErr) {
+		t.Errorf("got error %v, want ExpressionError", err)
+	}
+	if e.OutputType() != "" {
+		t.Errorf("got output type %s before type checked, want none", e.OutputType())
+	}
+}
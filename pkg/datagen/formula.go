@@ -9,9 +9,31 @@ import (
 
 var formulaLookups map[string]Formula
 
+// formulaOutputTypes maps formula names to the Go types of the values they return.
+var formulaOutputTypes map[string]string
+
+// formulaFactoryLookups maps names of stateful formulas to the functions creating them.
+var formulaFactoryLookups map[string]FormulaFactory
+
 // Formula represents a function that generates data based on a sequence number and arguments.
 type Formula func(faker *gofakeit.Faker, seqNum int, args ...string) (interface{}, error)
 
+// FormulaContext describes the column and the repetition a stateful formula is created for, so that its results can
+// continue from the previous repetitions.
+type FormulaContext struct {
+	// Key of the column
+	Key string
+	// Sequence number of the repetition
+	RepeatSeq int
+	// Maximum number of records of the column in a repetition
+	MaxNumRecords int
+}
+
+// FormulaFactory represents a function that creates a formula with its own state, for formulas whose results depend
+// on the results for previous records. A new formula is created each time a column is built, and it is called with
+// increasing sequence numbers.
+type FormulaFactory func(fc FormulaContext) Formula
+
 func init() {
 	initFormulaLookups()
 }
@@ -21,22 +43,49 @@ func initFormulaLookups() {
 	if formulaLookups == nil {
 		formulaLookups = make(map[string]Formula)
 	}
-	PutFormulaLookup("AddInt", AddInt)
-	PutFormulaLookup("AddFloat", AddFloat)
-	PutFormulaLookup("AddString", AddString)
-	PutFormulaLookup("And", And)
-	PutFormulaLookup("Or", Or)
-	PutFormulaLookup("XOrInt", XOrInt)
-	PutFormulaLookup("Copy", Copy)
-	PutFormulaLookup("CurrentTimeMs", CurrentTimeMs)
-	PutFormulaLookup("ToUnixMilli", ToUnixMilli)
-	PutFormulaLookup("AddRandomTimeMs", AddRandomTimeMs)
-	PutFormulaLookup("AddRandomNumber", AddRandomNumber)
-}
-
-// PutFormulaLookup adds a formula function to the formula lookups map.
-func PutFormulaLookup(formulaName string, formula Formula) {
+	if formulaOutputTypes == nil {
+		formulaOutputTypes = make(map[string]string)
+	}
+	if formulaFactoryLookups == nil {
+		formulaFactoryLookups = make(map[string]FormulaFactory)
+	}
+	PutFormulaLookup("AddInt", AddInt, "int")
+	PutFormulaLookup("AddFloat", AddFloat, "float64")
+	PutFormulaLookup("AddString", AddString, "string")
+	PutFormulaLookup("And", And, "bool")
+	PutFormulaLookup("Or", Or, "bool")
+	PutFormulaLookup("XOrInt", XOrInt, "int")
+	PutFormulaLookup("Copy", Copy, "")
+	PutFormulaLookup("CurrentTimeMs", CurrentTimeMs, "int64")
+	PutFormulaLookup("ToUnixMilli", ToUnixMilli, "int64")
+	PutFormulaLookup("AddRandomTimeMs", AddRandomTimeMs, "int64")
+	PutFormulaLookup("AddRandomNumber", AddRandomNumber, "int")
+	PutFormulaLookup(ExpressionFormulaName, EvaluateExpression, "")
+	PutFormulaFactoryLookup("TimeSeriesTimestamp", NewTimeSeriesTimestamp, "int64")
+	PutFormulaFactoryLookup("TimeSeriesValue", NewTimeSeriesValue, "float64")
+}
+
+// PutFormulaLookup adds a formula function and the Go type of its result to the formula lookups map. An empty
+// output type means the result has the same type as the column referenced by the first argument.
+func PutFormulaLookup(formulaName string, formula Formula, outputType string) {
 	formulaLookups[formulaName] = formula
+	formulaOutputTypes[formulaName] = outputType
+}
+
+// PutFormulaFactoryLookup adds a stateful formula to the formula lookups map, with the function creating it and the
+// Go type of its result. The formula in the formula lookups map is only used by callers not creating their own.
+func PutFormulaFactoryLookup(formulaName string, factory FormulaFactory, outputType string) {
+	formulaFactoryLookups[formulaName] = factory
+	PutFormulaLookup(formulaName, factory(FormulaContext{}), outputType)
+}
+
+// GetFormulaFactoryLookup retrieves the function creating a stateful formula by name, or nil if the formula is
+// stateless.
+func GetFormulaFactoryLookup(formulaName string) FormulaFactory {
+	if factory, ok := formulaFactoryLookups[formulaName]; ok {
+		return factory
+	}
+	return nil
 }
 
 // GetFormulaLookup retrieves a formula function from the formula lookups map by name.
@@ -47,6 +96,11 @@ func GetFormulaLookup(formulaName string) Formula {
 	return nil
 }
 
+// GetFormulaOutputType retrieves the Go type of the result of a formula function by name.
+func GetFormulaOutputType(formulaName string) string {
+	return formulaOutputTypes[formulaName]
+}
+
 // AddInt calculates the sum of integer values retrieved from the fake data cache.
 func AddInt(faker *gofakeit.Faker, seqNum int, args ...string) (interface{}, error) {
 	sum := 0
@@ -226,3 +280,10 @@ func AddRandomNumber(faker *gofakeit.Faker, seqNum int, args ...string) (interfa
 		return nil, err
 	}
 }
+
+// EvaluateExpression is the formula for expressions over other columns of the same record. The expression in the
+// first argument must be parsed and type checked against the columns, which NewSchemaBuilder does before replacing
+// this function with the compiled expression, so it only fails when called directly.
+func EvaluateExpression(faker *gofakeit.Faker, seqNum int, args ...string) (interface{}, error) {
+	return nil, FormulaArgsError(ExpressionFormulaName + " is not compiled")
+}
@@ -1,11 +1,11 @@
 package datagen
 
 import (
+	"math/rand/v2"
 	"os"
 	"path/filepath"
 
 	"github.com/brianvoe/gofakeit/v7"
-	"github.com/brianvoe/gofakeit/v7/source"
 
 	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
 )
@@ -13,38 +13,44 @@ import (
 // DataGeneratorJob is an interface for generating data.
 type DataGeneratorJob interface {
 	GenerateData(path string) error
+	// GetBytesWritten returns the total size of the files written by GenerateData.
+	GetBytesWritten() int64
 }
 
 // BuilderBasedDataGeneratorJob is a data generator job based on the build strategy.
 type BuilderBasedDataGeneratorJob struct {
 	RepeatStart int
 	RepeatEnd   int
+	Seed        int64
 	DataSet     *windtunnelv1alpha1.DataSet
 	SchemaMap   map[string]*windtunnelv1alpha1.Schema
+	// Total size of the files written by the job
+	BytesWritten int64
 }
 
 // NewBuilderBasedDataGeneratorJob creates a new BuilderBasedDataGeneratorJob instance.
-func NewBuilderBasedDataGeneratorJob(start, end int, dataSet *windtunnelv1alpha1.DataSet, schemaMap map[string]*windtunnelv1alpha1.Schema) DataGeneratorJob {
+func NewBuilderBasedDataGeneratorJob(start, end int, seed int64, dataSet *windtunnelv1alpha1.DataSet, schemaMap map[string]*windtunnelv1alpha1.Schema) DataGeneratorJob {
 	return &BuilderBasedDataGeneratorJob{
 		RepeatStart: start,
 		RepeatEnd:   end,
+		Seed:        seed,
 		DataSet:     dataSet,
 		SchemaMap:   schemaMap,
 	}
 }
 
+// NewFaker creates a gofakeit.Faker whose output is determined by the seed and the sequence number.
+func NewFaker(seed int64, seqNum int) *gofakeit.Faker {
+	return gofakeit.NewFaker(rand.NewPCG(uint64(seed), uint64(seqNum)), true)
+}
+
 // MakeOutputDir creates the output directory for a Schema in the DataSet.
 func MakeOutputDir(dataSet *windtunnelv1alpha1.DataSet, schemaIdx int, path string) error {
 	schPath := filepath.Join(path, dataSet.Spec.Schemas[schemaIdx].Name)
 
-	// Remove any existing directory
-	err := os.RemoveAll(schPath)
-	if err != nil {
-		return err
-	}
-
-	// Create new directory
-	err = os.MkdirAll(schPath, os.ModePerm)
+	// Create the directory if not exists. Do not remove the existing one, since it is shared by
+	// all indexes of the Job and may already contain files generated by other indexes.
+	err := os.MkdirAll(schPath, os.ModePerm)
 	if err != nil {
 		return err
 	}
@@ -68,12 +74,19 @@ func ApplyOperations(outputBuilder *OutputBuilder, seqNum int) error {
 func (dg *BuilderBasedDataGeneratorJob) GenerateData(path string) error {
 	var err error
 
-	// Initiate faker for gofakeit
-	faker := gofakeit.NewFaker(source.NewCrypto(), true)
+	// Schemas are built after the Schemas they depend on, so that the referenced data is available in the cache
+	schemaNames := make([]string, len(dg.DataSet.Spec.Schemas))
+	for i, schemaSelector := range dg.DataSet.Spec.Schemas {
+		schemaNames[i] = schemaSelector.Name
+	}
+	buildOrder, err := SortSchemasByDependency(schemaNames, dg.SchemaMap)
+	if err != nil {
+		return err
+	}
 
-	// Create SchemaBuilders and put them to cache
-	for _, schemaSelector := range dg.DataSet.Spec.Schemas {
-		schemaName := schemaSelector.Name
+	// Create SchemaBuilders and put them to cache, in the build order, so that the types of the referenced columns
+	// are known when expressions are type checked
+	for _, schemaName := range buildOrder {
 		schemaObj := dg.SchemaMap[schemaName]
 		schBldr, err := NewSchemaBuilder(schemaObj)
 		if err != nil {
@@ -101,11 +114,14 @@ func (dg *BuilderBasedDataGeneratorJob) GenerateData(path string) error {
 
 	// Generate data for each repeat
 	for i := dg.RepeatStart; i < dg.RepeatEnd; i++ {
+		// Initiate faker for gofakeit, seeded by the seed and the sequence number, so that each repeat
+		// produces the same output no matter which Job index it is assigned to
+		faker := NewFaker(dg.Seed, i)
 		// Initialize the randomness and cache for each SchemaBuilder
 		outputBuilder.SetRandomnessAndCache(faker, dg.DataSet)
 		// Build data for each Schema
-		for _, schBldr := range outputBuilder.SchBuilders {
-			err := schBldr.Build(faker)
+		for _, schemaName := range buildOrder {
+			err := GetSchemaBuilder(schemaName).Build(faker, i)
 			if err != nil {
 				return err
 			}
@@ -117,5 +133,11 @@ func (dg *BuilderBasedDataGeneratorJob) GenerateData(path string) error {
 		}
 	}
 
+	dg.BytesWritten = outputBuilder.BytesWritten
 	return nil
 }
+
+// GetBytesWritten returns the total size of the files written by GenerateData.
+func (dg *BuilderBasedDataGeneratorJob) GetBytesWritten() int64 {
+	return dg.BytesWritten
+}
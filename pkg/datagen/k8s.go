@@ -14,6 +14,14 @@ import (
 	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/config"
 )
 
+const (
+	// ContainerName is the name of the container of the data generator Job.
+	ContainerName = "data-generator"
+	// TerminationMessagePath is the file the data generator writes the total size of its output to,
+	// so that it is reported in the status of the container.
+	TerminationMessagePath = "/dev/termination-log"
+)
+
 var (
 	defaultImage       = config.GetString("dataGenerator.defaultImage")
 	defaultParallelism = config.GetInt32("dataGenerator.defaultParallelism")
@@ -21,8 +29,8 @@ var (
 	path               = config.GetString("dataGenerator.path")
 )
 
-// CreateJob creates a data generator Job based on the DataSet configuration.
-func CreateJob(jobName string, pvcName string, dataSet *windtunnelv1alpha1.DataSet, schemaMap map[string]*windtunnelv1alpha1.Schema) (*kbatch.Job, error) {
+// CreateJob creates a data generator Job based on the DataSet configuration and the seed to use.
+func CreateJob(jobName string, pvcName string, dataSet *windtunnelv1alpha1.DataSet, schemaMap map[string]*windtunnelv1alpha1.Schema, seed int64) (*kbatch.Job, error) {
 	// Calculate the number of parallel jobs and step size
 	parallelism := dataSet.Spec.Parallelism
 	if parallelism == 0 {
@@ -62,8 +70,9 @@ func CreateJob(jobName string, pvcName string, dataSet *windtunnelv1alpha1.DataS
 					RestartPolicy: corev1.RestartPolicyNever,
 					Containers: []corev1.Container{
 						{
-							Name:  "data-generator",
-							Image: image,
+							Name:                   ContainerName,
+							Image:                  image,
+							TerminationMessagePath: TerminationMessagePath,
 							Env: []corev1.EnvVar{
 								{
 									Name:  "JOB_STEP_SIZE",
@@ -73,6 +82,10 @@ func CreateJob(jobName string, pvcName string, dataSet *windtunnelv1alpha1.DataS
 									Name:  "TOTAL_REPEAT",
 									Value: strconv.FormatInt(int64(dataSet.Spec.NumberOfFiles), 10),
 								},
+								{
+									Name:  "SEED",
+									Value: strconv.FormatInt(seed, 10),
+								},
 								{
 									Name:  "DATASET",
 									Value: string(datasetBytes),
@@ -1,12 +1,13 @@
 package datagen
 
 import (
-	"archive/zip"
 	"bytes"
 	"encoding/binary"
 	"encoding/csv"
 	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -33,8 +34,37 @@ func initOpLookups() {
 	PutOpLookups("csv@cache", Raw2CSVAtCache)
 	PutOpLookups("binary", Raw2BinaryAtFile)
 	PutOpLookups("binary@cache", Raw2BinaryAtCache)
-	PutOpLookups("csv->zip", CSVAtCache2ZipAtFile)
-	PutOpLookups("binary->zip", BinaryAtCache2ZipAtFile)
+	PutOpLookups("json", Raw2JSONAtFile)
+	PutOpLookups("json@cache", Raw2JSONAtCache)
+	PutOpLookups("ndjson", Raw2NDJSONAtFile)
+	PutOpLookups("ndjson@cache", Raw2NDJSONAtCache)
+	PutOpLookups("parquet", Raw2ParquetAtFile)
+	PutOpLookups("parquet@cache", Raw2ParquetAtCache)
+	PutOpLookups("avro", Raw2AvroAtFile)
+	PutOpLookups("avro@cache", Raw2AvroAtCache)
+
+	// Register compression operations for each file format and compressed file format
+	compressedFileSchemaWriters := map[string]CompressedFileSchemaWriter{
+		"csv":    CSVAtCache2CompressedFileBySchema,
+		"binary": BinaryAtCache2CompressedFileBySchema,
+		"json": func(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int, cw CompressedFileWriter) error {
+			return JSONAtCache2CompressedFileBySchema(schemaName, seqNum, numFilesPerCompressedFile, numRecords, false, cw)
+		},
+		"ndjson": func(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int, cw CompressedFileWriter) error {
+			return JSONAtCache2CompressedFileBySchema(schemaName, seqNum, numFilesPerCompressedFile, numRecords, true, cw)
+		},
+		"parquet": func(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int, cw CompressedFileWriter) error {
+			return TypedAtCache2CompressedFileBySchema(schemaName, seqNum, numFilesPerCompressedFile, numRecords, "parquet", WriteParquetFile, cw)
+		},
+		"avro": func(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int, cw CompressedFileWriter) error {
+			return TypedAtCache2CompressedFileBySchema(schemaName, seqNum, numFilesPerCompressedFile, numRecords, "avro", WriteAvroFile, cw)
+		},
+	}
+	for fileFormat, writeBySchema := range compressedFileSchemaWriters {
+		for compressedFileFormat, compression := range compressionLookups {
+			PutOpLookups(fmt.Sprintf("%s->%s", fileFormat, compressedFileFormat), NewCompressionOperation(compression, writeBySchema))
+		}
+	}
 }
 
 // PutOpLookups registers an operation function with a name in the opLookups map.
@@ -59,6 +89,9 @@ func Raw2CSVAtFile(outputBuilder *OutputBuilder, seqNum int) error {
 		if err != nil {
 			return err
 		}
+		if err := outputBuilder.AddOutputFile(filePath); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -144,6 +177,9 @@ func Raw2BinaryAtFile(outputBuilder *OutputBuilder, seqNum int) error {
 		if err != nil {
 			return err
 		}
+		if err := outputBuilder.AddOutputFile(filePath); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -210,68 +246,12 @@ func Raw2BinaryAtCacheBySchema(numRecords int, schemaName string) error {
 	return nil
 }
 
-// CSVAtCache2ZipAtFile converts CSV data stored in cache to a zip file.
-func CSVAtCache2ZipAtFile(outputBuilder *OutputBuilder, seqNum int) error {
-	if outputBuilder.CompressPerSchema {
-		for _, schBldr := range outputBuilder.SchBuilders {
-			zipFilePath := filepath.Join(outputBuilder.Path, fmt.Sprintf("%s_%s_%d.zip", outputBuilder.Name, schBldr.SchemaName, seqNum))
-			if err := CSVAtCache2ZipAtFileBySchema(
-				schBldr.SchemaName,
-				seqNum,
-				schBldr.NumFilesPerCompressedFile,
-				schBldr.NumRecords,
-				true,
-				zipFilePath,
-				nil,
-			); err != nil {
-				return err
-			}
-		}
-		return nil
-	} else {
-		zipFilePath := filepath.Join(outputBuilder.Path, fmt.Sprintf("%s_%d.zip", outputBuilder.Name, seqNum))
-		zipFile, err := os.OpenFile(zipFilePath, os.O_CREATE|os.O_WRONLY, 0644)
-		if err != nil {
-			return err
-		}
-		defer zipFile.Close()
-		zipWriter := zip.NewWriter(zipFile)
-		defer zipWriter.Close()
-
-		for _, schBldr := range outputBuilder.SchBuilders {
-			if err := CSVAtCache2ZipAtFileBySchema(
-				schBldr.SchemaName,
-				seqNum,
-				schBldr.NumFilesPerCompressedFile,
-				schBldr.NumRecords,
-				false,
-				"",
-				zipWriter,
-			); err != nil {
-				return err
-			}
-		}
-
-		return nil
-	}
-}
-
-// CSVAtCache2ZipAtFileBySchema converts CSV data stored in cache to a zip file for a specific schema.
-func CSVAtCache2ZipAtFileBySchema(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int,
-	compressPerFile bool, zipFilePath string, zipWriter *zip.Writer) error {
-	if compressPerFile {
-		zipFile, err := os.OpenFile(zipFilePath, os.O_CREATE|os.O_WRONLY, 0644)
-		if err != nil {
-			return err
-		}
-		defer zipFile.Close()
-		zipWriter = zip.NewWriter(zipFile)
-		defer zipWriter.Close()
-	}
-
+// CSVAtCache2CompressedFileBySchema writes CSV data stored in cache to a compressed file for a specific schema.
+func CSVAtCache2CompressedFileBySchema(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int,
+	cw CompressedFileWriter) error {
 	for i := 0; i < numFilesPerCompressedFile; i++ {
 		fileName := fmt.Sprintf("%s_%d_%d.csv", schemaName, seqNum, i)
-		fWriter, err := zipWriter.Create(fileName)
+		fWriter, err := cw.Create(fileName)
 		if err != nil {
 			return err
 		}
@@ -313,88 +293,330 @@ func CSVAtCache2ZipAtFileBySchema(schemaName string, seqNum int, numFilesPerComp
 	return nil
 }
 
-// BinaryAtCache2ZipAtFile converts binary data stored in cache to a zip file.
-func BinaryAtCache2ZipAtFile(outputBuilder *OutputBuilder, seqNum int) error {
-	if outputBuilder.CompressPerSchema {
-		for _, schBldr := range outputBuilder.SchBuilders {
-			zipFilePath := filepath.Join(outputBuilder.Path, fmt.Sprintf("%s_%s_%d.zip", outputBuilder.Name, schBldr.SchemaName, seqNum))
-			if err := BinaryAtCache2ZipAtFileBySchema(
-				schBldr.SchemaName,
-				seqNum,
-				schBldr.NumFilesPerCompressedFile,
-				schBldr.NumRecords,
-				true,
-				zipFilePath,
-				nil,
-			); err != nil {
-				return err
+// BinaryAtCache2CompressedFileBySchema writes binary data stored in cache to a compressed file for a specific schema.
+func BinaryAtCache2CompressedFileBySchema(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int,
+	cw CompressedFileWriter) error {
+	colNames := GetColumnNames(schemaName)
+
+	for i := 0; i < numFilesPerCompressedFile; i++ {
+		var rows []byte
+		for j := 0; j < numRecords; j++ {
+			var row []byte
+			for _, key := range colNames {
+				bCol, _ := GetFakeData(key, i*numRecords+j)
+				if v, ok := bCol.([]byte); ok {
+					row = append(row, v...)
+				} else {
+					return TypeError(fmt.Sprintf("file %d, row %d, col %s", i, j, key))
+				}
 			}
+			rows = append(rows, row...)
+		}
+
+		fileName := fmt.Sprintf("%s_%d_%d.bin", schemaName, seqNum, i)
+		fWriter, err := cw.Create(fileName)
+		if err != nil {
+			return err
+		}
+		if _, err := fWriter.Write(rows); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Raw2JSONRecord converts a raw record of a specific schema to a JSON object. Keys of the object are in the same
+// order as the columns in the schema, and values keep their native JSON types.
+func Raw2JSONRecord(schemaName string, colNames []string, recordIdx int) ([]byte, error) {
+	prefixPattern := schemaName + "."
+	var buff bytes.Buffer
+	buff.WriteByte('{')
+	for j, key := range colNames {
+		fakeData, err := GetFakeData(key, recordIdx)
+		if err != nil {
+			return nil, err
+		}
+		bKey, err := json.Marshal(strings.TrimPrefix(key, prefixPattern))
+		if err != nil {
+			return nil, err
+		}
+		bValue, err := json.Marshal(fakeData)
+		if err != nil {
+			return nil, err
+		}
+		if j > 0 {
+			buff.WriteByte(',')
+		}
+		buff.Write(bKey)
+		buff.WriteByte(':')
+		buff.Write(bValue)
+	}
+	buff.WriteByte('}')
+	return buff.Bytes(), nil
+}
+
+// writeJSONRecords writes JSON records to a writer, either as a JSON array or as newline-delimited JSON.
+func writeJSONRecords(w io.Writer, records [][]byte, newlineDelimited bool) error {
+	var buff bytes.Buffer
+	if !newlineDelimited {
+		buff.WriteByte('[')
+	}
+	for i, record := range records {
+		if !newlineDelimited && i > 0 {
+			buff.WriteByte(',')
+		}
+		buff.Write(record)
+		if newlineDelimited {
+			buff.WriteByte('\n')
+		}
+	}
+	if !newlineDelimited {
+		buff.WriteByte(']')
+	}
+	_, err := w.Write(buff.Bytes())
+	return err
+}
+
+// Raw2JSONAtFile converts raw data to JSON format and writes it to a file.
+func Raw2JSONAtFile(outputBuilder *OutputBuilder, seqNum int) error {
+	for _, schBldr := range outputBuilder.SchBuilders {
+		filePath := filepath.Join(schBldr.Path, fmt.Sprintf("%s_%s_%d.json", outputBuilder.Name, schBldr.SchemaName, seqNum))
+		err := Raw2JSONAtFileBySchema(schBldr.NumRecords, schBldr.SchemaName, filePath, false)
+		if err != nil {
+			return err
+		}
+		if err := outputBuilder.AddOutputFile(filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Raw2NDJSONAtFile converts raw data to NDJSON format and writes it to a file.
+func Raw2NDJSONAtFile(outputBuilder *OutputBuilder, seqNum int) error {
+	for _, schBldr := range outputBuilder.SchBuilders {
+		filePath := filepath.Join(schBldr.Path, fmt.Sprintf("%s_%s_%d.ndjson", outputBuilder.Name, schBldr.SchemaName, seqNum))
+		err := Raw2JSONAtFileBySchema(schBldr.NumRecords, schBldr.SchemaName, filePath, true)
+		if err != nil {
+			return err
+		}
+		if err := outputBuilder.AddOutputFile(filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Raw2JSONAtFileBySchema converts raw data to JSON or NDJSON format for a specific schema and writes it to a file.
+func Raw2JSONAtFileBySchema(numRecords int, schemaName, filePath string, newlineDelimited bool) error {
+	colNames := GetColumnNames(schemaName)
+	records := make([][]byte, numRecords)
+	for i := 0; i < numRecords; i++ {
+		record, err := Raw2JSONRecord(schemaName, colNames, i)
+		if err != nil {
+			return err
+		}
+		records[i] = record
+	}
+
+	outFile, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	return writeJSONRecords(outFile, records, newlineDelimited)
+}
+
+// Raw2JSONAtCache converts raw data to JSON format and stores it in cache.
+func Raw2JSONAtCache(outputBuilder *OutputBuilder, seqNum int) error {
+	for _, schBldr := range outputBuilder.SchBuilders {
+		err := Raw2JSONAtCacheBySchema(schBldr.TotalNumRecords, schBldr.SchemaName)
+		if err != nil {
+			return err
 		}
-		return nil
-	} else {
-		zipFilePath := filepath.Join(outputBuilder.Path, fmt.Sprintf("%s_%d.zip", outputBuilder.Name, seqNum))
-		zipFile, err := os.OpenFile(zipFilePath, os.O_CREATE|os.O_WRONLY, 0644)
+	}
+	return nil
+}
+
+// Raw2NDJSONAtCache converts raw data to NDJSON format and stores it in cache.
+// Records are stored the same way as JSON format, the delimiters are added when they are written to a file.
+func Raw2NDJSONAtCache(outputBuilder *OutputBuilder, seqNum int) error {
+	return Raw2JSONAtCache(outputBuilder, seqNum)
+}
+
+// Raw2JSONAtCacheBySchema converts raw data to JSON objects for a specific schema and stores them in cache.
+func Raw2JSONAtCacheBySchema(numRecords int, schemaName string) error {
+	colNames := GetColumnNames(schemaName)
+	for i := 0; i < numRecords; i++ {
+		record, err := Raw2JSONRecord(schemaName, colNames, i)
 		if err != nil {
 			return err
 		}
-		defer zipFile.Close()
-		zipWriter := zip.NewWriter(zipFile)
-		defer zipWriter.Close()
-
-		for _, schBldr := range outputBuilder.SchBuilders {
-			if err := BinaryAtCache2ZipAtFileBySchema(
-				schBldr.SchemaName,
-				seqNum,
-				schBldr.NumFilesPerCompressedFile,
-				schBldr.NumRecords,
-				false,
-				"",
-				zipWriter,
-			); err != nil {
+		PutFakeData(schemaName, i, record)
+	}
+	return nil
+}
+
+// JSONAtCache2CompressedFileBySchema writes JSON or NDJSON data stored in cache to a compressed file for a specific
+// schema.
+func JSONAtCache2CompressedFileBySchema(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int,
+	newlineDelimited bool, cw CompressedFileWriter) error {
+	ext := "json"
+	if newlineDelimited {
+		ext = "ndjson"
+	}
+
+	for i := 0; i < numFilesPerCompressedFile; i++ {
+		records := make([][]byte, numRecords)
+		for j := 0; j < numRecords; j++ {
+			row, err := GetFakeData(schemaName, i*numRecords+j)
+			if err != nil {
 				return err
 			}
+			if record, ok := row.([]byte); ok {
+				records[j] = record
+			} else {
+				return TypeError(fmt.Sprintf("file %d, row %d", i, j))
+			}
 		}
 
-		return nil
+		fileName := fmt.Sprintf("%s_%d_%d.%s", schemaName, seqNum, i, ext)
+		fWriter, err := cw.Create(fileName)
+		if err != nil {
+			return err
+		}
+		if err := writeJSONRecords(fWriter, records, newlineDelimited); err != nil {
+			return err
+		}
 	}
+
+	return nil
+}
+
+// Raw2ParquetAtFile converts raw data to Parquet format and writes it to a file.
+func Raw2ParquetAtFile(outputBuilder *OutputBuilder, seqNum int) error {
+	return raw2TypedAtFile(outputBuilder, seqNum, "parquet", WriteParquetFile)
 }
 
-// BinaryAtCache2ZipAtFileBySchema converts binary data stored in cache to a zip file for a specific schema.
-func BinaryAtCache2ZipAtFileBySchema(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int,
-	compressPerFile bool, zipFilePath string, zipWriter *zip.Writer) error {
-	if compressPerFile {
-		zipFile, err := os.OpenFile(zipFilePath, os.O_CREATE|os.O_WRONLY, 0644)
+// Raw2AvroAtFile converts raw data to Avro format and writes it to a file.
+func Raw2AvroAtFile(outputBuilder *OutputBuilder, seqNum int) error {
+	return raw2TypedAtFile(outputBuilder, seqNum, "avro", WriteAvroFile)
+}
+
+// raw2TypedAtFile converts raw data to a typed file format and writes it to a file.
+func raw2TypedAtFile(outputBuilder *OutputBuilder, seqNum int, ext string, writeFile TypedFileWriter) error {
+	for _, schBldr := range outputBuilder.SchBuilders {
+		filePath := filepath.Join(schBldr.Path, fmt.Sprintf("%s_%s_%d.%s", outputBuilder.Name, schBldr.SchemaName, seqNum, ext))
+		err := Raw2TypedAtFileBySchema(schBldr.NumRecords, schBldr.SchemaName, filePath, writeFile)
 		if err != nil {
 			return err
 		}
-		defer zipFile.Close()
-		zipWriter = zip.NewWriter(zipFile)
-		defer zipWriter.Close()
+		if err := outputBuilder.AddOutputFile(filePath); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// Raw2TypedAtFileBySchema converts raw data to a typed file format for a specific schema and writes it to a file.
+func Raw2TypedAtFileBySchema(numRecords int, schemaName, filePath string, writeFile TypedFileWriter) error {
 	colNames := GetColumnNames(schemaName)
+	colTypes, err := GetColumnTypes(colNames)
+	if err != nil {
+		return err
+	}
+
+	records := make([][]interface{}, numRecords)
+	for i := 0; i < numRecords; i++ {
+		record, err := Raw2TypedRecord(colNames, colTypes, i)
+		if err != nil {
+			return err
+		}
+		records[i] = record
+	}
+
+	outFile, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	return writeFile(outFile, schemaName, GetFieldNames(schemaName, colNames), colTypes, records)
+}
+
+// Raw2ParquetAtCache converts raw data to typed records for Parquet format and stores them in cache.
+func Raw2ParquetAtCache(outputBuilder *OutputBuilder, seqNum int) error {
+	return raw2TypedAtCache(outputBuilder)
+}
+
+// Raw2AvroAtCache converts raw data to typed records for Avro format and stores them in cache.
+func Raw2AvroAtCache(outputBuilder *OutputBuilder, seqNum int) error {
+	return raw2TypedAtCache(outputBuilder)
+}
+
+// raw2TypedAtCache converts raw data to typed records and stores them in cache.
+// Records are stored the same way for all typed file formats, the files are encoded when they are compressed.
+func raw2TypedAtCache(outputBuilder *OutputBuilder) error {
+	for _, schBldr := range outputBuilder.SchBuilders {
+		err := Raw2TypedAtCacheBySchema(schBldr.TotalNumRecords, schBldr.SchemaName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Raw2TypedAtCacheBySchema converts raw data to typed records for a specific schema and stores them in cache.
+func Raw2TypedAtCacheBySchema(numRecords int, schemaName string) error {
+	colNames := GetColumnNames(schemaName)
+	colTypes, err := GetColumnTypes(colNames)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < numRecords; i++ {
+		record, err := Raw2TypedRecord(colNames, colTypes, i)
+		if err != nil {
+			return err
+		}
+		PutFakeData(schemaName, i, record)
+	}
+	return nil
+}
+
+// TypedAtCache2CompressedFileBySchema writes typed records stored in cache to files of a typed file format in a
+// compressed file for a specific schema.
+func TypedAtCache2CompressedFileBySchema(schemaName string, seqNum int, numFilesPerCompressedFile int, numRecords int,
+	ext string, writeFile TypedFileWriter, cw CompressedFileWriter) error {
+	colNames := GetColumnNames(schemaName)
+	colTypes, err := GetColumnTypes(colNames)
+	if err != nil {
+		return err
+	}
+	fieldNames := GetFieldNames(schemaName, colNames)
 
 	for i := 0; i < numFilesPerCompressedFile; i++ {
-		var rows []byte
+		records := make([][]interface{}, numRecords)
 		for j := 0; j < numRecords; j++ {
-			var row []byte
-			for _, key := range colNames {
-				bCol, _ := GetFakeData(key, i*numRecords+j)
-				if v, ok := bCol.([]byte); ok {
-					row = append(row, v...)
-				} else {
-					return TypeError(fmt.Sprintf("file %d, row %d, col %s", i, j, key))
-				}
+			row, err := GetFakeData(schemaName, i*numRecords+j)
+			if err != nil {
+				return err
+			}
+			if record, ok := row.([]interface{}); ok {
+				records[j] = record
+			} else {
+				return TypeError(fmt.Sprintf("file %d, row %d", i, j))
 			}
-			rows = append(rows, row...)
 		}
 
-		fileName := fmt.Sprintf("%s_%d_%d.bin", schemaName, seqNum, i)
-		fWriter, err := zipWriter.Create(fileName)
+		fileName := fmt.Sprintf("%s_%d_%d.%s", schemaName, seqNum, i, ext)
+		fWriter, err := cw.Create(fileName)
 		if err != nil {
 			return err
 		}
-		if _, err := fWriter.Write(rows); err != nil {
+		if err := writeFile(fWriter, schemaName, fieldNames, colTypes, records); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,183 @@
+package datagen
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// timeSeriesStarts maps keys of TimeSeriesTimestamp columns to the start of their series, which is the same in all
+// repetitions.
+var timeSeriesStarts = make(map[string]int64)
+
+// NewTimeSeriesTimestamp creates a formula generating monotonically increasing timestamps in Unix milliseconds for
+// each entity of a time series. The arguments are:
+//   - start: the first timestamp, as a date, a RFC 3339 time, or Unix milliseconds
+//   - interval: the duration between consecutive timestamps of an entity, like "1s" or "5m"
+//   - jitter (optional): the maximum deviation from the interval, less than the interval, like "200ms"
+//   - entity (optional): key of the column identifying the entity, like a device ID, each having its own series
+//
+// With jitter, the first timestamp of each entity is also delayed by up to the jitter, so that entities do not report
+// at the same time.
+//
+// Each repetition starts after the time window of the previous ones, which is long enough for the maximum number of
+// records, so that the series continue from file to file while each repetition is generated independently.
+func NewTimeSeriesTimestamp(fc FormulaContext) Formula {
+	// Last timestamp of each entity
+	lastTimestamps := make(map[string]int64)
+
+	return func(faker *gofakeit.Faker, seqNum int, args ...string) (interface{}, error) {
+		if len(args) < 2 || len(args) > 4 {
+			return nil, FormulaArgsError("TimeSeriesTimestamp")
+		}
+		start, err := parseTimeMs(args[0])
+		if err != nil {
+			return nil, FormulaArgsError("TimeSeriesTimestamp.start")
+		}
+		interval, err := time.ParseDuration(args[1])
+		if err != nil || interval.Milliseconds() <= 0 {
+			return nil, FormulaArgsError("TimeSeriesTimestamp.interval")
+		}
+		var jitter time.Duration
+		if len(args) > 2 && args[2] != "" {
+			jitter, err = time.ParseDuration(args[2])
+			if err != nil || jitter < 0 || jitter >= interval {
+				return nil, FormulaArgsError("TimeSeriesTimestamp.jitter")
+			}
+		}
+		entity, err := getTimeSeriesEntity(seqNum, args, 3)
+		if err != nil {
+			return nil, err
+		}
+
+		jitterMs := int(jitter.Milliseconds())
+		last, ok := lastTimestamps[entity]
+		var timestamp int64
+		if ok {
+			// The deviation is less than the interval, so the timestamps keep increasing
+			timestamp = last + interval.Milliseconds() + int64(faker.Number(-jitterMs, jitterMs))
+		} else {
+			// An entity has at most the maximum number of records, each at most the interval and the jitter after
+			// the previous one, so its timestamps stay before the window of the next repetition
+			window := int64(fc.MaxNumRecords) * (interval + jitter).Milliseconds()
+			timestamp = start + int64(fc.RepeatSeq)*window + int64(faker.Number(0, jitterMs))
+			timeSeriesStarts[fc.Key] = start
+		}
+		lastTimestamps[entity] = timestamp
+		return timestamp, nil
+	}
+}
+
+// NewTimeSeriesValue creates a formula generating the values of a time series, as the sum of a trend, a seasonal
+// sine wave, and Gaussian noise, at the timestamps of another column. The arguments are:
+//   - timestamp: key of the column with the timestamps in Unix milliseconds, like a TimeSeriesTimestamp column
+//   - base: the value at the first timestamp, without seasonality and noise
+//   - trend (optional): the change of the value per hour since the start of the TimeSeriesTimestamp column, or since
+//     the timestamp of the first record of the repetition for other columns
+//   - amplitude (optional): the amplitude of the seasonal wave
+//   - period (optional): the period of the seasonal wave, like "24h", defaulting to a day
+//   - noise (optional): the standard deviation of the noise
+//
+// The seasonal wave is aligned to the Unix epoch, so that all entities share the same cycle, like a daily one.
+func NewTimeSeriesValue(fc FormulaContext) Formula {
+	// First timestamp of the series, where the trend starts
+	var firstTimestamp *int64
+
+	return func(faker *gofakeit.Faker, seqNum int, args ...string) (interface{}, error) {
+		if len(args) < 2 || len(args) > 6 {
+			return nil, FormulaArgsError("TimeSeriesValue")
+		}
+		base, err := getFloatArg(args, 1, "TimeSeriesValue.base")
+		if err != nil {
+			return nil, err
+		}
+		trend, err := getFloatArg(args, 2, "TimeSeriesValue.trend")
+		if err != nil {
+			return nil, err
+		}
+		amplitude, err := getFloatArg(args, 3, "TimeSeriesValue.amplitude")
+		if err != nil {
+			return nil, err
+		}
+		period := 24 * time.Hour
+		if len(args) > 4 && args[4] != "" {
+			period, err = time.ParseDuration(args[4])
+			if err != nil || period.Milliseconds() <= 0 {
+				return nil, FormulaArgsError("TimeSeriesValue.period")
+			}
+		}
+		noise, err := getFloatArg(args, 5, "TimeSeriesValue.noise")
+		if err != nil || noise < 0 {
+			return nil, FormulaArgsError("TimeSeriesValue.noise")
+		}
+
+		fakeData, err := GetFakeData(args[0], seqNum)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := toColumnValue(ColumnTypeLong, fakeData)
+		if !ok {
+			return nil, TypeError(args[0])
+		}
+		timestamp := v.(int64)
+		if firstTimestamp == nil {
+			// The TimeSeriesTimestamp column is built before, and the trend continues across its repetitions
+			if start, ok := timeSeriesStarts[args[0]]; ok {
+				firstTimestamp = &start
+			} else {
+				firstTimestamp = &timestamp
+			}
+		}
+
+		hours := float64(timestamp-*firstTimestamp) / float64(time.Hour.Milliseconds())
+		phase := float64(timestamp%period.Milliseconds()) / float64(period.Milliseconds())
+		value := base + trend*hours + amplitude*math.Sin(2*math.Pi*phase)
+		if noise > 0 {
+			value += noise * distributionRand(faker).NormFloat64()
+		}
+		return value, nil
+	}
+}
+
+// getTimeSeriesEntity retrieves the entity of a record from the column whose key is the argument at the given
+// index, or an empty string if there is no such argument, so that all records belong to the same series.
+func getTimeSeriesEntity(seqNum int, args []string, idx int) (string, error) {
+	if len(args) <= idx || args[idx] == "" {
+		return "", nil
+	}
+	fakeData, err := GetFakeData(args[idx], seqNum)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(fakeData), nil
+}
+
+// getFloatArg parses the optional argument at the given index as a float, defaulting to 0 if it is not given.
+func getFloatArg(args []string, idx int, name string) (float64, error) {
+	if len(args) <= idx || args[idx] == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(args[idx], 64)
+	if err != nil {
+		return 0, FormulaArgsError(name)
+	}
+	return v, nil
+}
+
+// parseTimeMs parses a date, a RFC 3339 time, or Unix milliseconds to Unix milliseconds.
+func parseTimeMs(s string) (int64, error) {
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ms, nil
+	}
+	var err error
+	for _, layout := range dateLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t.UnixMilli(), nil
+		}
+	}
+	return 0, err
+}
@@ -0,0 +1,231 @@
+package datagen
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hamba/avro"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// ColumnType represents the type of a column in the typed file formats, i.e., Parquet and Avro.
+type ColumnType string
+
+const (
+	ColumnTypeLong    ColumnType = "long"
+	ColumnTypeDouble  ColumnType = "double"
+	ColumnTypeBoolean ColumnType = "boolean"
+	ColumnTypeBytes   ColumnType = "bytes"
+	ColumnTypeString  ColumnType = "string"
+)
+
+// parquetTypes maps ColumnType to the type definitions in the Parquet metadata.
+var parquetTypes = map[ColumnType]string{
+	ColumnTypeLong:    "type=INT64",
+	ColumnTypeDouble:  "type=DOUBLE",
+	ColumnTypeBoolean: "type=BOOLEAN",
+	ColumnTypeBytes:   "type=BYTE_ARRAY",
+	ColumnTypeString:  "type=BYTE_ARRAY, convertedtype=UTF8",
+}
+
+// avroMagic is the magic bytes at the beginning of an Avro object container file.
+var avroMagic = []byte{'O', 'b', 'j', 1}
+
+// invalidFieldNameChars matches the characters not allowed in Avro names.
+var invalidFieldNameChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// TypedFileWriter represents a function that writes typed records of a specific schema to a writer.
+type TypedFileWriter func(w io.Writer, schemaName string, fieldNames []string, colTypes []ColumnType, records [][]interface{}) error
+
+// GetColumnType maps the Go type of the values generated for a column to a ColumnType. Types without a
+// counterpart, like time.Time and slices, are written as strings, in the same format as in CSV files.
+func GetColumnType(outputType string) ColumnType {
+	switch outputType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		return ColumnTypeLong
+	case "float", "float32", "float64":
+		return ColumnTypeDouble
+	case "bool":
+		return ColumnTypeBoolean
+	case "[]byte":
+		return ColumnTypeBytes
+	default:
+		return ColumnTypeString
+	}
+}
+
+// GetColumnTypes retrieves the ColumnType of each column by its key.
+func GetColumnTypes(colNames []string) ([]ColumnType, error) {
+	colTypes := make([]ColumnType, len(colNames))
+	for i, key := range colNames {
+		outputType, err := GetOutputType(key)
+		if err != nil {
+			return nil, err
+		}
+		colTypes[i] = GetColumnType(outputType)
+	}
+	return colTypes, nil
+}
+
+// GetFieldNames converts the column names of a specific schema to field names of the typed file formats.
+// Characters not allowed in Avro names are replaced with underscores.
+func GetFieldNames(schemaName string, colNames []string) []string {
+	prefixPattern := schemaName + "."
+	fieldNames := make([]string, len(colNames))
+	for i, colName := range colNames {
+		fieldNames[i] = toFieldName(strings.TrimPrefix(colName, prefixPattern))
+	}
+	return fieldNames
+}
+
+// toFieldName converts a name to a valid Avro name.
+func toFieldName(name string) string {
+	name = invalidFieldNameChars.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// toColumnValue converts a value to the Go type used by the typed file formats for the ColumnType.
+func toColumnValue(colType ColumnType, v interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	switch colType {
+	case ColumnTypeLong:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int(), true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return int64(rv.Uint()), true
+		}
+	case ColumnTypeDouble:
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return rv.Float(), true
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return float64(rv.Int()), true
+		}
+	case ColumnTypeBoolean:
+		if b, ok := v.(bool); ok {
+			return b, true
+		}
+	case ColumnTypeBytes:
+		if b, ok := v.([]byte); ok {
+			return b, true
+		}
+		return []byte(fmt.Sprint(v)), true
+	case ColumnTypeString:
+		return fmt.Sprint(v), true
+	}
+	return nil, false
+}
+
+// Raw2TypedRecord converts a raw record to a list of values typed according to the ColumnType of each column.
+func Raw2TypedRecord(colNames []string, colTypes []ColumnType, recordIdx int) ([]interface{}, error) {
+	record := make([]interface{}, len(colNames))
+	for j, key := range colNames {
+		fakeData, err := GetFakeData(key, recordIdx)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := toColumnValue(colTypes[j], fakeData)
+		if !ok {
+			return nil, TypeError(fmt.Sprintf("%s, row %d", key, recordIdx))
+		}
+		record[j] = v
+	}
+	return record, nil
+}
+
+// WriteParquetFile writes typed records to a writer as a Parquet file.
+func WriteParquetFile(w io.Writer, schemaName string, fieldNames []string, colTypes []ColumnType, records [][]interface{}) error {
+	md := make([]string, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		md[i] = fmt.Sprintf("name=%s, %s", fieldName, parquetTypes[colTypes[i]])
+	}
+
+	pw, err := writer.NewCSVWriterFromWriter(md, w, 1)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		// Rows are buffered by the writer until it stops, so they must not be reused
+		row := make([]interface{}, len(record))
+		for j, v := range record {
+			// Parquet byte arrays are represented by strings
+			if b, ok := v.([]byte); ok {
+				v = string(b)
+			}
+			row[j] = v
+		}
+		if err := pw.Write(row); err != nil {
+			return err
+		}
+	}
+	return pw.WriteStop()
+}
+
+// WriteAvroFile writes typed records to a writer as an Avro object container file. All records are written in a
+// single block without compression. The sync marker is derived from the records, so the same records always
+// produce the same file.
+func WriteAvroFile(w io.Writer, schemaName string, fieldNames []string, colTypes []ColumnType, records [][]interface{}) error {
+	type avroField struct {
+		Name string     `json:"name"`
+		Type ColumnType `json:"type"`
+	}
+	fields := make([]avroField, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		fields[i] = avroField{Name: fieldName, Type: colTypes[i]}
+	}
+	bSchema, err := json.Marshal(map[string]interface{}{
+		"type":   "record",
+		"name":   toFieldName(schemaName),
+		"fields": fields,
+	})
+	if err != nil {
+		return err
+	}
+	schema, err := avro.Parse(string(bSchema))
+	if err != nil {
+		return err
+	}
+
+	var data bytes.Buffer
+	datum := make(map[string]interface{}, len(fieldNames))
+	for _, record := range records {
+		for j, v := range record {
+			datum[fieldNames[j]] = v
+		}
+		bRecord, err := avro.Marshal(schema, datum)
+		if err != nil {
+			return err
+		}
+		data.Write(bRecord)
+	}
+	sync := md5.Sum(data.Bytes())
+
+	// Write the header with the metadata in a fixed order
+	aw := avro.NewWriter(w, 512)
+	aw.Write(avroMagic)
+	aw.WriteLong(2)
+	aw.WriteString("avro.codec")
+	aw.WriteBytes([]byte("null"))
+	aw.WriteString("avro.schema")
+	aw.WriteBytes([]byte(schema.String()))
+	aw.WriteLong(0)
+	aw.Write(sync[:])
+
+	if len(records) > 0 {
+		aw.WriteLong(int64(len(records)))
+		aw.WriteLong(int64(data.Len()))
+		aw.Write(data.Bytes())
+		aw.Write(sync[:])
+	}
+	return aw.Flush()
+}
@@ -22,7 +22,7 @@ var (
 	filenameEndpoint        = config.GetString("loadGenerator.filename.endpoint")
 	filenamePlainText       = config.GetString("loadGenerator.filename.plainText")
 	filenameDataSet         = config.GetString("loadGenerator.filename.dataSet")
-	filenameLoadPattern     = config.GetString("loadGenerator.filename.loadPattern")
+	filenameScenario        = config.GetString("loadGenerator.filename.scenario")
 	copierImage             = config.GetString("loadGenerator.copier.image")
 	defaultRunnerImage      = config.GetString("loadGenerator.testRun.defaultRunnerImage")
 	defaultStarterImage     = config.GetString("loadGenerator.testRun.defaultStarterImage")
@@ -30,17 +30,114 @@ var (
 	testRunRWArgs           = config.GetString("loadGenerator.testRun.remoteWriteArgs")
 	testRunRWEnvVarName     = config.GetString("loadGenerator.testRun.remoteWriteEnvVar.name")
 	testRunRWEnvVarValue    = config.GetString("loadGenerator.testRun.remoteWriteEnvVar.value")
+	testRunTrendStatsName   = config.GetString("loadGenerator.testRun.trendStatsEnvVar.name")
+	testRunTrendStatsValue  = config.GetString("loadGenerator.testRun.trendStatsEnvVar.value")
 	defaultStorageSize      = config.GetString("dataGenerator.defaultStorageSize")
 )
 
+// Names of the environment variables passing the credentials of HTTP endpoints to the K6 runners.
+// They must match the names used by the load generator scripts.
+const (
+	envHTTPSecretHeaderPrefix   = "PLANTD_HTTP_SECRET_HEADER_"
+	envHTTPBasicUsername        = "PLANTD_HTTP_BASIC_USERNAME"
+	envHTTPBasicPassword        = "PLANTD_HTTP_BASIC_PASSWORD"
+	envHTTPOAuth2ClientID       = "PLANTD_HTTP_OAUTH2_CLIENT_ID"
+	envHTTPOAuth2ClientSecret   = "PLANTD_HTTP_OAUTH2_CLIENT_SECRET"
+	envHTTPHMACKey              = "PLANTD_HTTP_HMAC_KEY"
+	envHTTPClientCertificate    = "PLANTD_HTTP_TLS_CERT"
+	envHTTPClientCertificateKey = "PLANTD_HTTP_TLS_KEY"
+)
+
+// GetParallelism returns the number of K6 runners for the EndpointSpec.
+func GetParallelism(endpointSpec *windtunnelv1alpha1.EndpointSpec) int32 {
+	if endpointSpec.Parallelism > 0 {
+		return endpointSpec.Parallelism
+	}
+	return 1
+}
+
+// getRunnerLoadPattern returns the LoadPattern to be used by the K6 runners.
+// K6 splits the rates and VUs across the runners by execution segments, so a runner could get no VUs at all
+// and drop its share of the iterations. To avoid that, the VUs are increased to at least one per runner.
+func getRunnerLoadPattern(loadPattern *windtunnelv1alpha1.LoadPattern, parallelism int32) *windtunnelv1alpha1.LoadPattern {
+	if parallelism <= 1 {
+		return loadPattern
+	}
+	runnerLoadPattern := loadPattern.DeepCopy()
+	runnerLoadPattern.Spec.PreAllocatedVUs = max(runnerLoadPattern.Spec.PreAllocatedVUs, int64(parallelism))
+	runnerLoadPattern.Spec.MaxVUs = max(runnerLoadPattern.Spec.MaxVUs, int64(parallelism))
+	return runnerLoadPattern
+}
+
+// k6Scenario defines the options of a K6 scenario. Only the options used by the executor are set.
+type k6Scenario struct {
+	Executor        windtunnelv1alpha1.LoadPatternExecutor `json:"executor"`
+	StartRate       int64                                  `json:"startRate,omitempty"`
+	Rate            int64                                  `json:"rate,omitempty"`
+	TimeUnit        string                                 `json:"timeUnit,omitempty"`
+	PreAllocatedVUs int64                                  `json:"preAllocatedVUs,omitempty"`
+	MaxVUs          int64                                  `json:"maxVUs,omitempty"`
+	VUs             int64                                  `json:"vus,omitempty"`
+	StartVUs        int64                                  `json:"startVUs,omitempty"`
+	Iterations      int64                                  `json:"iterations,omitempty"`
+	Duration        string                                 `json:"duration,omitempty"`
+	MaxDuration     string                                 `json:"maxDuration,omitempty"`
+	Stages          []windtunnelv1alpha1.Stage             `json:"stages,omitempty"`
+}
+
+// getRunnerScenario returns the K6 scenario of the executor used by the LoadPattern, to be run by the K6 runners.
+// K6 rejects options not supported by the executor, so only the ones used by the executor are copied.
+func getRunnerScenario(loadPattern *windtunnelv1alpha1.LoadPattern, parallelism int32) *k6Scenario {
+	spec := &getRunnerLoadPattern(loadPattern, parallelism).Spec
+	switch spec.Executor {
+	case windtunnelv1alpha1.LoadPatternExecutorConstantVUs:
+		return &k6Scenario{
+			Executor: spec.Executor,
+			VUs:      spec.VUs,
+			Duration: spec.Duration,
+		}
+	case windtunnelv1alpha1.LoadPatternExecutorRampingVUs:
+		return &k6Scenario{
+			Executor: spec.Executor,
+			StartVUs: spec.StartVUs,
+			Stages:   spec.Stages,
+		}
+	case windtunnelv1alpha1.LoadPatternExecutorConstantArrivalRate:
+		return &k6Scenario{
+			Executor:        spec.Executor,
+			Rate:            spec.Rate,
+			TimeUnit:        spec.TimeUnit,
+			Duration:        spec.Duration,
+			PreAllocatedVUs: spec.PreAllocatedVUs,
+			MaxVUs:          spec.MaxVUs,
+		}
+	case windtunnelv1alpha1.LoadPatternExecutorPerVUIterations, windtunnelv1alpha1.LoadPatternExecutorSharedIterations:
+		return &k6Scenario{
+			Executor:    spec.Executor,
+			VUs:         spec.VUs,
+			Iterations:  spec.Iterations,
+			MaxDuration: spec.MaxDuration,
+		}
+	default:
+		return &k6Scenario{
+			Executor:        windtunnelv1alpha1.LoadPatternExecutorRampingArrivalRate,
+			StartRate:       spec.StartRate,
+			TimeUnit:        spec.TimeUnit,
+			PreAllocatedVUs: spec.PreAllocatedVUs,
+			MaxVUs:          spec.MaxVUs,
+			Stages:          spec.Stages,
+		}
+	}
+}
+
 // CreateConfigMapWithPlainText creates a ConfigMap for EndpointSpec with plain text data.
-func CreateConfigMapWithPlainText(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint, text string, loadPattern *windtunnelv1alpha1.LoadPattern, protocol windtunnelv1alpha1.EndpointProtocol) (*corev1.ConfigMap, error) {
+func CreateConfigMapWithPlainText(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint, text string, loadPattern *windtunnelv1alpha1.LoadPattern, parallelism int32, protocol windtunnelv1alpha1.EndpointProtocol) (*corev1.ConfigMap, error) {
 	jsonEndpoint, err := json.Marshal(pipelineEndpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	jsonLoadPattern, err := json.Marshal(loadPattern)
+	jsonScenario, err := json.Marshal(getRunnerScenario(loadPattern, parallelism))
 	if err != nil {
 		return nil, err
 	}
@@ -51,16 +148,16 @@ func CreateConfigMapWithPlainText(experiment *windtunnelv1alpha1.Experiment, end
 			Name:      utils.GetTestRunName(experiment.Name, endpointIdx),
 		},
 		Data: map[string]string{
-			filenameScript:      config.GetString(fmt.Sprintf("loadGenerator.script.%s.plainText", protocol)),
-			filenameEndpoint:    string(jsonEndpoint),
-			filenamePlainText:   text,
-			filenameLoadPattern: string(jsonLoadPattern),
+			filenameScript:    config.GetString(fmt.Sprintf("loadGenerator.script.%s.plainText", protocol)),
+			filenameEndpoint:  string(jsonEndpoint),
+			filenamePlainText: text,
+			filenameScenario:  string(jsonScenario),
 		},
 	}, nil
 }
 
 // CreateConfigMapWithDataSet creates a ConfigMap for EndpointSpec with DataSet.
-func CreateConfigMapWithDataSet(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint, dataSet *windtunnelv1alpha1.DataSet, loadPattern *windtunnelv1alpha1.LoadPattern, protocol windtunnelv1alpha1.EndpointProtocol) (*corev1.ConfigMap, error) {
+func CreateConfigMapWithDataSet(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint, dataSet *windtunnelv1alpha1.DataSet, loadPattern *windtunnelv1alpha1.LoadPattern, parallelism int32, protocol windtunnelv1alpha1.EndpointProtocol) (*corev1.ConfigMap, error) {
 	jsonEndpoint, err := json.Marshal(pipelineEndpoint)
 	if err != nil {
 		return nil, err
@@ -71,7 +168,7 @@ func CreateConfigMapWithDataSet(experiment *windtunnelv1alpha1.Experiment, endpo
 		return nil, err
 	}
 
-	jsonLoadPattern, err := json.Marshal(loadPattern)
+	jsonScenario, err := json.Marshal(getRunnerScenario(loadPattern, parallelism))
 	if err != nil {
 		return nil, err
 	}
@@ -82,10 +179,10 @@ func CreateConfigMapWithDataSet(experiment *windtunnelv1alpha1.Experiment, endpo
 			Name:      utils.GetTestRunName(experiment.Name, endpointIdx),
 		},
 		Data: map[string]string{
-			filenameScript:      config.GetString(fmt.Sprintf("loadGenerator.script.%s.dataSet", protocol)),
-			filenameEndpoint:    string(jsonEndpoint),
-			filenameDataSet:     string(jsonDataSet),
-			filenameLoadPattern: string(jsonLoadPattern),
+			filenameScript:   config.GetString(fmt.Sprintf("loadGenerator.script.%s.dataSet", protocol)),
+			filenameEndpoint: string(jsonEndpoint),
+			filenameDataSet:  string(jsonDataSet),
+			filenameScenario: string(jsonScenario),
 		},
 	}, nil
 }
@@ -101,6 +198,15 @@ func CreatePVC(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, endpo
 		storageSize = resource.MustParse(defaultStorageSize)
 	}
 
+	// The PVC is shared by all runners, which may be scheduled to different nodes
+	accessMode := endpointSpec.StorageAccessMode
+	if accessMode == "" {
+		accessMode = corev1.ReadWriteOnce
+		if GetParallelism(endpointSpec) > 1 {
+			accessMode = corev1.ReadWriteMany
+		}
+	}
+
 	pvc := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: experiment.Namespace,
@@ -108,8 +214,9 @@ func CreatePVC(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, endpo
 		},
 		Spec: corev1.PersistentVolumeClaimSpec{
 			AccessModes: []corev1.PersistentVolumeAccessMode{
-				corev1.ReadWriteOnce,
+				accessMode,
 			},
+			StorageClassName: endpointSpec.StorageClassName,
 			Resources: corev1.VolumeResourceRequirements{
 				Requests: corev1.ResourceList{
 					corev1.ResourceStorage: storageSize,
@@ -192,8 +299,66 @@ func CreateCopierJob(experiment *windtunnelv1alpha1.Experiment, endpointIdx int,
 	}
 }
 
+// getSecretEnvVar returns an environment variable whose value is read from the key of a Secret.
+func getSecretEnvVar(name string, selector corev1.SecretKeySelector) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: selector.DeepCopy(),
+		},
+	}
+}
+
+// getHTTPCredentialEnv returns the environment variables passing the credentials of the HTTP endpoint to the K6
+// runners. The values are read from the Secrets by Kubernetes, so that they are not stored in the ConfigMap.
+// The secret headers are passed in the order they are defined.
+func getHTTPCredentialEnv(pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint) []corev1.EnvVar {
+	if pipelineEndpoint == nil || pipelineEndpoint.HTTP == nil {
+		return nil
+	}
+
+	var env []corev1.EnvVar
+	for i, secretHeader := range pipelineEndpoint.HTTP.SecretHeaders {
+		env = append(env, getSecretEnvVar(fmt.Sprintf("%s%d", envHTTPSecretHeaderPrefix, i), secretHeader.SecretKeyRef))
+	}
+
+	auth := pipelineEndpoint.HTTP.Auth
+	if auth == nil {
+		return env
+	}
+	if auth.Basic != nil {
+		env = append(env,
+			getSecretEnvVar(envHTTPBasicUsername, auth.Basic.Username),
+			getSecretEnvVar(envHTTPBasicPassword, auth.Basic.Password),
+		)
+	}
+	if auth.OAuth2 != nil {
+		env = append(env,
+			getSecretEnvVar(envHTTPOAuth2ClientID, auth.OAuth2.ClientID),
+			getSecretEnvVar(envHTTPOAuth2ClientSecret, auth.OAuth2.ClientSecret),
+		)
+	}
+	if auth.HMAC != nil {
+		env = append(env, getSecretEnvVar(envHTTPHMACKey, auth.HMAC.Key))
+	}
+	if auth.ClientCertificateSecretRef != nil {
+		env = append(env,
+			getSecretEnvVar(envHTTPClientCertificate, corev1.SecretKeySelector{
+				LocalObjectReference: *auth.ClientCertificateSecretRef,
+				Key:                  corev1.TLSCertKey,
+			}),
+			getSecretEnvVar(envHTTPClientCertificateKey, corev1.SecretKeySelector{
+				LocalObjectReference: *auth.ClientCertificateSecretRef,
+				Key:                  corev1.TLSPrivateKeyKey,
+			}),
+		)
+	}
+	return env
+}
+
 // CreateTestRun creates a TestRun for the EndpointSpec.
-func CreateTestRun(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, endpointSpec *windtunnelv1alpha1.EndpointSpec) *k6v1alpha1.TestRun {
+// The credentials of the PipelineEndpoint are passed to the K6 runners as environment variables.
+func CreateTestRun(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, endpointSpec *windtunnelv1alpha1.EndpointSpec, pipelineEndpoint *windtunnelv1alpha1.PipelineEndpoint) *k6v1alpha1.TestRun {
 	runnerImage := experiment.Spec.K6RunnerImage
 	if runnerImage == "" {
 		runnerImage = defaultRunnerImage
@@ -209,13 +374,13 @@ func CreateTestRun(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, e
 		initializerImage = defaultInitializerImage
 	}
 
-	return &k6v1alpha1.TestRun{
+	testRun := &k6v1alpha1.TestRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: experiment.Namespace,
 			Name:      utils.GetTestRunName(experiment.Name, endpointIdx),
 		},
 		Spec: k6v1alpha1.TestRunSpec{
-			Parallelism: 1,
+			Parallelism: GetParallelism(endpointSpec),
 			Arguments: fmt.Sprintf("%s --tag experiment=%s/%s --tag endpoint=%s",
 				testRunRWArgs, experiment.Namespace, experiment.Name, endpointSpec.EndpointName,
 			),
@@ -226,6 +391,10 @@ func CreateTestRun(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, e
 						Name:  testRunRWEnvVarName,
 						Value: testRunRWEnvVarValue,
 					},
+					{
+						Name:  testRunTrendStatsName,
+						Value: testRunTrendStatsValue,
+					},
 				},
 			},
 			Starter: k6v1alpha1.Pod{
@@ -236,4 +405,13 @@ func CreateTestRun(experiment *windtunnelv1alpha1.Experiment, endpointIdx int, e
 			},
 		},
 	}
+	testRun.Spec.Runner.Env = append(testRun.Spec.Runner.Env, getHTTPCredentialEnv(pipelineEndpoint)...)
+	if endpointSpec.RunnerResources != nil {
+		testRun.Spec.Runner.Resources = *endpointSpec.RunnerResources
+	}
+	if endpointSpec.RunnerNodeSelector != nil {
+		testRun.Spec.Runner.NodeSelector = endpointSpec.RunnerNodeSelector
+	}
+
+	return testRun
 }
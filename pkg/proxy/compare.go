@@ -0,0 +1,49 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
+	"github.com/CarnegieMellon-PlantD/PlantD-operator/pkg/analysis"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CompareExperiments compares the results of an Experiment with those of another Experiment in the same namespace
+// as the baseline, using the regression tolerances of the former. Both Experiments must be completed.
+func CompareExperiments(ctx context.Context, c client.Client, qa *QueryAgent, namespace, name, baselineName string) (*ComparisonResponse, error) {
+	experiment, err := getCompletedExperiment(ctx, c, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	baseline, err := getCompletedExperiment(ctx, c, namespace, baselineName)
+	if err != nil {
+		return nil, err
+	}
+
+	querier := &analysis.Querier{PromAPI: qa.PromAPI}
+	verdict, results := querier.Compare(ctx, experiment, baseline, experiment.Spec.RegressionTolerance)
+	return &ComparisonResponse{
+		Verdict: verdict,
+		Results: results,
+	}, nil
+}
+
+// getCompletedExperiment retrieves an Experiment of the provided namespace and name, and checks that it is completed.
+func getCompletedExperiment(ctx context.Context, c client.Client, namespace, name string) (*windtunnelv1alpha1.Experiment, error) {
+	experiment := &windtunnelv1alpha1.Experiment{}
+	if err := c.Get(ctx, types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}, experiment); err != nil {
+		return nil, err
+	}
+
+	if experiment.Status.JobStatus != windtunnelv1alpha1.ExperimentCompleted ||
+		experiment.Status.StartTime == nil || experiment.Status.CompletionTime == nil {
+		return nil, fmt.Errorf("experiment \"%s/%s\" is not completed", namespace, name)
+	}
+	return experiment, nil
+}
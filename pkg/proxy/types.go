@@ -3,6 +3,8 @@ package proxy
 import (
 	"encoding/json"
 	"time"
+
+	windtunnelv1alpha1 "github.com/CarnegieMellon-PlantD/PlantD-operator/api/v1alpha1"
 )
 
 // ErrorResponse defines the response to send when error occurs.
@@ -118,3 +120,11 @@ type TriChanResponse struct {
 	// Result is a list of TriChanDataPoint
 	Result []*TriChanDataPoint `json:"result"`
 }
+
+// ComparisonResponse defines the response to send for the comparison of an Experiment with its baseline.
+type ComparisonResponse struct {
+	// Verdict is the verdict of the comparison
+	Verdict windtunnelv1alpha1.ComparisonVerdict `json:"verdict"`
+	// Results is a list of the results of the comparison of each metric
+	Results []windtunnelv1alpha1.ComparisonResult `json:"results"`
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
 
@@ -135,7 +136,16 @@ func GetSampleDataSet(ctx context.Context, c client.Client, namespace, name stri
 	if err != nil {
 		return nil, fmt.Errorf("while creating temporary directory: %w", err)
 	}
-	job := datagen.NewBuilderBasedDataGeneratorJob(0, 1, dataSet, schemaMap)
+	// Use the same seed as the data generator job if known, so that the sample is reproducible
+	var seed int64
+	if dataSet.Spec.Seed != nil {
+		seed = *dataSet.Spec.Seed
+	} else if dataSet.Status.Seed != nil {
+		seed = *dataSet.Status.Seed
+	} else {
+		seed = rand.Int64()
+	}
+	job := datagen.NewBuilderBasedDataGeneratorJob(0, 1, seed, dataSet, schemaMap)
 	if err := job.GenerateData(tmpPath); err != nil {
 		return nil, fmt.Errorf("while generating data: %w", err)
 	}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -71,3 +72,17 @@ func GetSimulationJobName(simulationName string) string {
 func GetEndDetectorJobName(experimentName string) string {
 	return fmt.Sprintf("%s-enddetect", experimentName)
 }
+
+// GetScheduledExperimentName returns the name of the Experiment created by the ExperimentSchedule for the scheduled time.
+// The scheduled time is represented in minutes since the Unix epoch, which is unique since Cron schedules are in minutes.
+func GetScheduledExperimentName(experimentScheduleName string, scheduledTime time.Time) string {
+	return fmt.Sprintf("%s-%d", experimentScheduleName, scheduledTime.Unix()/60)
+}
+
+// GetCapacitySearchExperimentName returns the name of the Experiment and LoadPattern testing a target rate
+// for the CapacitySearch.
+// Note that to shorten the name, only the last 4 hex digits of the point index are used.
+// It is safe because we limit the number of points in the CapacitySearch to be no more than 65535.
+func GetCapacitySearchExperimentName(capacitySearchName string, pointIdx int) string {
+	return fmt.Sprintf("%s-%x", capacitySearchName, (pointIdx+1)%0x10000)
+}